@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// publicPrefixes son los métodos que no requieren token: reflexión y health checks.
+var publicPrefixes = []string{
+	"/grpc.reflection.",
+	"/grpc.health.v1.Health/",
+}
+
+func isPublic(method string) bool {
+	for _, prefix := range publicPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticate valida el token de la llamada y regresa ctx con el principal.
+func (v *Verifier) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if v.cfg.Disabled || isPublic(method) {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, status.Error(codes.Unauthenticated, `authorization must be "Bearer <token>"`)
+	}
+
+	p, err := v.Verify(strings.TrimSpace(token))
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+	return NewContext(ctx, p), nil
+}
+
+// UnaryServerInterceptor rechaza con Unauthenticated las llamadas sin un token válido.
+func (v *Verifier) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := v.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor es la versión para streams de UnaryServerInterceptor.
+func (v *Verifier) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := v.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &principalStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type principalStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *principalStream) Context() context.Context { return s.ctx }
+
+// serviceTokenTTL es la vigencia de los tokens de servicio firmados con AUTH_HMAC_SECRET.
+const serviceTokenTTL = 5 * time.Minute
+
+// ServiceRole es el rol de los tokens de servicio.
+const ServiceRole = "service"
+
+// ServiceToken da el token de las llamadas que un servicio hace por su cuenta, fuera de
+// una llamada de usuario: AUTH_SERVICE_TOKEN si está definido, o uno de vida corta
+// firmado con AUTH_HMAC_SECRET.
+type ServiceToken struct {
+	cfg     Config
+	subject string
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewServiceToken regresa el token de servicio con sub igual a subject.
+func NewServiceToken(cfg Config, subject string) *ServiceToken {
+	return &ServiceToken{cfg: cfg, subject: subject}
+}
+
+// Token regresa el token vigente, o "" si no hay forma de obtener uno.
+func (t *ServiceToken) Token() (string, error) {
+	if t.cfg.Disabled {
+		return "", nil
+	}
+	if t.cfg.ServiceToken != "" {
+		return t.cfg.ServiceToken, nil
+	}
+	if len(t.cfg.HMACSecret) == 0 {
+		return "", nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	// Se renueva con un minuto de margen para que no caduque en vuelo.
+	if t.token != "" && now.Add(time.Minute).Before(t.expires) {
+		return t.token, nil
+	}
+
+	c := claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   t.subject,
+			Issuer:    t.cfg.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(serviceTokenTTL)),
+		},
+		Roles: []string{ServiceRole},
+	}
+	if t.cfg.Audience != "" {
+		c.Audience = jwt.ClaimStrings{t.cfg.Audience}
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString(t.cfg.HMACSecret)
+	if err != nil {
+		return "", err
+	}
+	t.token, t.expires = token, now.Add(serviceTokenTTL)
+	return token, nil
+}
+
+// UnaryClientInterceptor agrega el token a las llamadas salientes: el del usuario si la
+// llamada viene de una autenticada, o el de ts si no.
+func UnaryClientInterceptor(ts *ServiceToken) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var token string
+		if p, ok := FromContext(ctx); ok {
+			token = p.Token
+		} else {
+			var err error
+			if token, err = ts.Token(); err != nil {
+				return status.Errorf(codes.Internal, "signing service token: %v", err)
+			}
+		}
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+// jwksReloadInterval es lo mínimo que se espera entre dos lecturas del archivo JWKS cuando
+// llega un kid desconocido (rotación de llaves).
+const jwksReloadInterval = 30 * time.Second
+
+// jwk es una llave de un JWKS (RFC 7517/7518). Solo se leen los campos de llaves públicas.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS regresa las llaves de firma de raw por kid. Las llaves con use distinto de
+// "sig" se ignoran.
+func parseJWKS(raw []byte) (map[string]any, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	keys := map[string]any{}
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("JWKS key %d (kid %q): %w", i, k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("invalid base64url value %q", s)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (k jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("RSA exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported EC curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("EC point is not on curve %s", k.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported OKP curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// keySet son las llaves públicas del JWKS. Si vienen de un archivo se vuelven a leer
+// cuando llega un kid desconocido, a lo más una vez cada jwksReloadInterval.
+type keySet struct {
+	path string
+
+	mu       sync.Mutex
+	keys     map[string]any
+	loadedAt time.Time
+}
+
+func newKeySet(cfg Config) (*keySet, error) {
+	ks := &keySet{path: cfg.JWKSFile, keys: map[string]any{}}
+	if cfg.JWKS != "" {
+		keys, err := parseJWKS([]byte(cfg.JWKS))
+		if err != nil {
+			return nil, fmt.Errorf("AUTH_JWKS: %w", err)
+		}
+		ks.keys = keys
+	}
+	if ks.path != "" {
+		if err := ks.reload(); err != nil {
+			return nil, err
+		}
+	}
+	return ks, nil
+}
+
+// reload vuelve a leer el archivo; las llaves de AUTH_JWKS se conservan. Se llama con mu
+// tomado o durante la construcción.
+func (ks *keySet) reload() error {
+	raw, err := os.ReadFile(ks.path)
+	if err != nil {
+		return fmt.Errorf("AUTH_JWKS_FILE: %w", err)
+	}
+	keys, err := parseJWKS(raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ks.path, err)
+	}
+	for kid, key := range keys {
+		ks.keys[kid] = key
+	}
+	ks.loadedAt = time.Now()
+	return nil
+}
+
+func (ks *keySet) empty() bool {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	return len(ks.keys) == 0
+}
+
+// lookup regresa la llave de kid. Un kid vacío solo se acepta si el JWKS tiene una sola
+// llave.
+func (ks *keySet) lookup(kid string) (any, bool) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	find := func() (any, bool) {
+		if kid == "" && len(ks.keys) == 1 {
+			for _, key := range ks.keys {
+				return key, true
+			}
+		}
+		key, ok := ks.keys[kid]
+		return key, ok
+	}
+
+	if key, ok := find(); ok {
+		return key, true
+	}
+	if ks.path == "" || time.Since(ks.loadedAt) < jwksReloadInterval {
+		return nil, false
+	}
+	if err := ks.reload(); err != nil {
+		// Se conservan las llaves anteriores; el siguiente intento espera otro intervalo.
+		ks.loadedAt = time.Now()
+		return nil, false
+	}
+	return find()
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Roles del claim roles.
+const (
+	// RoleStudent solo administra sus propias reservas.
+	RoleStudent = "student"
+	// RoleLibrarian puede ver y cancelar las reservas de cualquier usuario.
+	RoleLibrarian = "librarian"
+	// RoleAdmin además administra los cubículos y sus horarios.
+	RoleAdmin = "admin"
+)
+
+// HasAnyRole indica si el principal tiene alguno de roles.
+func (p *Principal) HasAnyRole(roles ...string) bool {
+	for _, role := range roles {
+		if p.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// Policy asigna a cada método (nombre completo, p. ej. "/cubicles.MetadataService/CreateMetadata")
+// los roles que pueden llamarlo. Los métodos que no aparecen quedan abiertos a cualquier
+// usuario autenticado; las reglas por dueño de cada recurso las aplica el servicio.
+type Policy map[string][]string
+
+// authorize rechaza con PermissionDenied si el principal de ctx no tiene ninguno de los
+// roles que pide method. Sin principal (AUTH_DISABLED o método público) no se revisa nada.
+func (pol Policy) authorize(ctx context.Context, method string) error {
+	roles, ok := pol[method]
+	if !ok {
+		return nil
+	}
+	p, ok := FromContext(ctx)
+	if !ok || p.HasAnyRole(roles...) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "%s requires one of the roles %v", method, roles)
+}
+
+// UnaryServerInterceptor aplica la política; va después del interceptor de autenticación.
+func (pol Policy) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := pol.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor es la versión para streams de UnaryServerInterceptor.
+func (pol Policy) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := pol.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	createMetadata = "/cubicles.MetadataService/CreateMetadata"
+	getMetadata    = "/cubicles.MetadataService/GetMetadata"
+)
+
+var testPolicy = Policy{createMetadata: {RoleAdmin}}
+
+func TestPolicyAuthorize(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *Principal // nil: llamada sin autenticar (AUTH_DISABLED)
+		method    string
+		want      codes.Code
+	}{
+		{"student creating metadata", &Principal{Subject: "s1", Roles: []string{RoleStudent}}, createMetadata, codes.PermissionDenied},
+		{"librarian creating metadata", &Principal{Subject: "l1", Roles: []string{RoleLibrarian}}, createMetadata, codes.PermissionDenied},
+		{"service creating metadata", &Principal{Subject: "reservation", Roles: []string{ServiceRole}}, createMetadata, codes.PermissionDenied},
+		{"no roles", &Principal{Subject: "u1"}, createMetadata, codes.PermissionDenied},
+		{"admin creating metadata", &Principal{Subject: "a1", Roles: []string{RoleAdmin}}, createMetadata, codes.OK},
+		{"admin among other roles", &Principal{Subject: "a1", Roles: []string{RoleStudent, RoleAdmin}}, createMetadata, codes.OK},
+		{"no principal", nil, createMetadata, codes.OK},
+		{"method not in the policy", &Principal{Subject: "s1", Roles: []string{RoleStudent}}, getMetadata, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = NewContext(ctx, tt.principal)
+			}
+			if got := status.Code(testPolicy.authorize(ctx, tt.method)); got != tt.want {
+				t.Errorf("authorize = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyInterceptorStopsDeniedCalls(t *testing.T) {
+	ctx := NewContext(context.Background(), &Principal{Subject: "s1", Roles: []string{RoleStudent}})
+	called := false
+	handler := func(context.Context, any) (any, error) {
+		called = true
+		return nil, nil
+	}
+
+	_, err := testPolicy.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: createMetadata}, handler)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("interceptor error = %v, want PermissionDenied", err)
+	}
+	if called {
+		t.Error("handler ran for a denied call")
+	}
+}
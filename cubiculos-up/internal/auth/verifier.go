@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// leeway es la tolerancia de reloj al validar exp, nbf e iat.
+const leeway = 30 * time.Second
+
+// claims son los claims que se leen del token.
+type claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles,omitempty"`
+}
+
+// Verifier valida JWT firmados con las llaves de la configuración.
+type Verifier struct {
+	cfg    Config
+	keys   *keySet
+	parser *jwt.Parser
+}
+
+// NewVerifier construye un Verifier a partir de cfg. Regresa ErrNoKeys si la
+// autenticación está activa y no hay ninguna llave.
+func NewVerifier(cfg Config) (*Verifier, error) {
+	v := &Verifier{cfg: cfg}
+	if cfg.Disabled {
+		return v, nil
+	}
+
+	keys, err := newKeySet(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if keys.empty() && len(cfg.HMACSecret) == 0 {
+		return nil, ErrNoKeys
+	}
+	v.keys = keys
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512",
+			"ES256", "ES384", "ES512", "EdDSA", "HS256", "HS384", "HS512"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(leeway),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	v.parser = jwt.NewParser(opts...)
+	return v, nil
+}
+
+// Disabled indica si la autenticación está desactivada.
+func (v *Verifier) Disabled() bool { return v.cfg.Disabled }
+
+// Verify valida token y regresa su principal.
+func (v *Verifier) Verify(token string) (*Principal, error) {
+	if v.cfg.Disabled {
+		return nil, errors.New("auth: authentication is disabled")
+	}
+
+	var c claims
+	if _, err := v.parser.ParseWithClaims(token, &c, v.keyFunc); err != nil {
+		return nil, err
+	}
+	if c.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+	return &Principal{Subject: c.Subject, Roles: c.Roles, Token: token}, nil
+}
+
+// keyFunc elige la llave del token y revisa que corresponda al algoritmo del header, para
+// que un token HS256 no pueda usar una llave pública como secreto.
+func (v *Verifier) keyFunc(t *jwt.Token) (any, error) {
+	if _, ok := t.Method.(*jwt.SigningMethodHMAC); ok {
+		if len(v.cfg.HMACSecret) == 0 {
+			return nil, fmt.Errorf("%s tokens are not accepted", t.Method.Alg())
+		}
+		return v.cfg.HMACSecret, nil
+	}
+
+	kid, _ := t.Header["kid"].(string)
+	key, ok := v.keys.lookup(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	var matches bool
+	switch t.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		_, matches = key.(*rsa.PublicKey)
+	case *jwt.SigningMethodECDSA:
+		_, matches = key.(*ecdsa.PublicKey)
+	case *jwt.SigningMethodEd25519:
+		_, matches = key.(ed25519.PublicKey)
+	}
+	if !matches {
+		return nil, fmt.Errorf("key %q cannot verify %s tokens", kid, t.Method.Alg())
+	}
+	return key, nil
+}
@@ -0,0 +1,232 @@
+// Package config carga la configuración de los servicios desde flags, variables de
+// entorno y un archivo JSON opcional, con esta prioridad: flag > entorno > archivo >
+// default. Cada binario registra los campos que usa (puerto, base de datos, servicios
+// internos, ...) y Load los llena y valida de una vez.
+//
+// El archivo se indica con -config o CONFIG_FILE; sus llaves son los nombres de los flags:
+//
+//	{"port": 50052, "db-host": "postgres-service", "db-max-open-conns": 20}
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// field es un campo registrado en el Loader.
+type field struct {
+	name string
+	env  string
+	set  func(string) error
+	// flagValue es el valor recibido por flag; se aplica al final para que gane.
+	flagValue *string
+}
+
+// Loader reúne los campos de un binario. Se crea con NewLoader, se registran los campos
+// y se llama Load una vez.
+type Loader struct {
+	fs     *flag.FlagSet
+	fields []*field
+	checks []func() error
+	file   string
+}
+
+// NewLoader crea un Loader para el binario name.
+func NewLoader(name string) *Loader {
+	l := &Loader{fs: flag.NewFlagSet(name, flag.ContinueOnError)}
+	l.fs.StringVar(&l.file, "config", os.Getenv("CONFIG_FILE"), "JSON config file (env CONFIG_FILE)")
+	return l
+}
+
+// flagValue guarda el texto del flag sin aplicarlo; Load lo aplica después del archivo y
+// del entorno.
+type flagValue struct {
+	raw *string
+	def string
+}
+
+func (v *flagValue) String() string {
+	if v.raw != nil && *v.raw != "" {
+		return *v.raw
+	}
+	return v.def
+}
+
+func (v *flagValue) Set(s string) error {
+	*v.raw = s
+	return nil
+}
+
+// register agrega un campo con su flag -name y su variable env.
+func (l *Loader) register(name, env, def, usage string, set func(string) error) {
+	f := &field{name: name, env: env, set: set, flagValue: new(string)}
+	if env != "" {
+		usage = fmt.Sprintf("%s (env %s)", usage, env)
+	}
+	l.fs.Var(&flagValue{raw: f.flagValue, def: def}, name, usage)
+	l.fields = append(l.fields, f)
+}
+
+// String registra un campo de texto.
+func (l *Loader) String(p *string, name, env, def, usage string) {
+	*p = def
+	l.register(name, env, def, usage, func(s string) error {
+		*p = s
+		return nil
+	})
+}
+
+// Int registra un campo entero.
+func (l *Loader) Int(p *int, name, env string, def int, usage string) {
+	*p = def
+	l.register(name, env, strconv.Itoa(def), usage, func(s string) error {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("expected an integer, got %q", s)
+		}
+		*p = n
+		return nil
+	})
+}
+
+// Duration registra una duración en el formato de time.ParseDuration ("90s", "15m").
+func (l *Loader) Duration(p *time.Duration, name, env string, def time.Duration, usage string) {
+	*p = def
+	l.register(name, env, def.String(), usage, func(s string) error {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("expected a duration like 90s or 15m, got %q", s)
+		}
+		*p = d
+		return nil
+	})
+}
+
+// Bool registra un campo booleano ("true"/"false").
+func (l *Loader) Bool(p *bool, name, env string, def bool, usage string) {
+	*p = def
+	l.register(name, env, strconv.FormatBool(def), usage, func(s string) error {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("expected true or false, got %q", s)
+		}
+		*p = b
+		return nil
+	})
+}
+
+// Check agrega una validación que Load corre después de cargar todos los campos.
+func (l *Loader) Check(check func() error) {
+	l.checks = append(l.checks, check)
+}
+
+// Positive es una validación común: d debe ser mayor que cero.
+func Positive(name string, d *time.Duration) func() error {
+	return func() error {
+		if *d <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", name, *d)
+		}
+		return nil
+	}
+}
+
+// Load interpreta args (normalmente os.Args[1:]), el archivo y el entorno, y corre las
+// validaciones. Los errores de todos los campos se regresan juntos.
+func (l *Loader) Load(args []string) error {
+	l.fs.SetOutput(io.Discard)
+	if err := l.fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			l.fs.SetOutput(os.Stderr)
+			l.fs.PrintDefaults()
+		}
+		return err
+	}
+
+	var errs []error
+	apply := func(f *field, source, value string) {
+		if err := f.set(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s (%s): %w", f.name, source, err))
+		}
+	}
+
+	if l.file != "" {
+		values, err := readFile(l.file)
+		if err != nil {
+			return err
+		}
+		for _, f := range l.fields {
+			if v, ok := values[f.name]; ok {
+				apply(f, l.file, v)
+				delete(values, f.name)
+			}
+		}
+		for name := range values {
+			errs = append(errs, fmt.Errorf("%s: unknown setting %q", l.file, name))
+		}
+	}
+
+	for _, f := range l.fields {
+		if f.env == "" {
+			continue
+		}
+		if v, ok := os.LookupEnv(f.env); ok && v != "" {
+			apply(f, "env "+f.env, v)
+		}
+	}
+
+	set := map[string]bool{}
+	l.fs.Visit(func(fl *flag.Flag) { set[fl.Name] = true })
+	for _, f := range l.fields {
+		if set[f.name] {
+			apply(f, "flag -"+f.name, *f.flagValue)
+		}
+	}
+
+	if len(errs) == 0 {
+		for _, check := range l.checks {
+			if err := check(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Args regresa los argumentos que no son flags, por ejemplo "migrate up".
+func (l *Loader) Args() []string {
+	return l.fs.Args()
+}
+
+// readFile lee un objeto JSON plano; números y booleanos se convierten a texto para
+// interpretarlos igual que los flags.
+func readFile(path string) (map[string]string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]any
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	out := make(map[string]string, len(values))
+	for name, v := range values {
+		switch v := v.(type) {
+		case string:
+			out[name] = v
+		case float64:
+			out[name] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			out[name] = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("%s: %s must be a string, number or boolean", path, name)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Listen es el puerto en el que escucha el servidor gRPC.
+type Listen struct {
+	Port int
+}
+
+// Listen registra -port / LISTEN_PORT.
+func (l *Loader) Listen(defPort int) *Listen {
+	c := &Listen{}
+	l.Int(&c.Port, "port", "LISTEN_PORT", defPort, "gRPC listen port")
+	l.Check(func() error {
+		if c.Port < 1 || c.Port > 65535 {
+			return fmt.Errorf("port must be between 1 and 65535, got %d", c.Port)
+		}
+		return nil
+	})
+	return c
+}
+
+// Addr es la dirección para net.Listen, en todas las interfaces.
+func (c *Listen) Addr() string {
+	return ":" + strconv.Itoa(c.Port)
+}
+
+// Health es el puerto de las probes de Kubernetes y cada cuánto se revisan las
+// dependencias.
+type Health struct {
+	Port     int
+	Interval time.Duration
+}
+
+// Health registra -health-port / HEALTH_PORT y -health-interval / HEALTH_INTERVAL. Con
+// puerto 0 el servicio de health solo se atiende en el puerto principal.
+func (l *Loader) Health() *Health {
+	c := &Health{}
+	l.Int(&c.Port, "health-port", "HEALTH_PORT", 8081, "plaintext port serving only grpc.health.v1 for probes; 0 disables it")
+	l.Duration(&c.Interval, "health-interval", "HEALTH_INTERVAL", 5*time.Second, "how often dependencies are checked")
+	l.Check(func() error {
+		if c.Port < 0 || c.Port > 65535 {
+			return fmt.Errorf("health-port must be between 0 and 65535, got %d", c.Port)
+		}
+		return nil
+	})
+	l.Check(Positive("health-interval", &c.Interval))
+	return c
+}
+
+// Addr es la dirección para net.Listen del puerto de health.
+func (c *Health) Addr() string {
+	return ":" + strconv.Itoa(c.Port)
+}
+
+// Shutdown controla el apagado: cuánto se espera a que Kubernetes deje de mandar tráfico
+// y cuánto se da a las llamadas en curso. La suma debe quedar por debajo de
+// terminationGracePeriodSeconds (30s por default).
+type Shutdown struct {
+	Delay   time.Duration
+	Timeout time.Duration
+}
+
+// Shutdown registra -shutdown-delay / SHUTDOWN_DELAY y -shutdown-timeout / SHUTDOWN_TIMEOUT.
+func (l *Loader) Shutdown() *Shutdown {
+	c := &Shutdown{}
+	l.Duration(&c.Delay, "shutdown-delay", "SHUTDOWN_DELAY", 5*time.Second, "time between reporting NOT_SERVING and stopping the server")
+	l.Duration(&c.Timeout, "shutdown-timeout", "SHUTDOWN_TIMEOUT", 20*time.Second, "time given to in-flight calls before they are cut")
+	l.Check(func() error {
+		if c.Delay < 0 {
+			return fmt.Errorf("shutdown-delay must not be negative, got %s", c.Delay)
+		}
+		return nil
+	})
+	l.Check(Positive("shutdown-timeout", &c.Timeout))
+	return c
+}
+
+// DB es la conexión a Postgres. DATABASE_URL, si está definido, reemplaza a las piezas
+// sueltas (DB_HOST, DB_PORT, ...).
+type DB struct {
+	URL      string
+	Host     string
+	Port     int
+	Name     string
+	User     string
+	Password string
+	SSLMode  string
+
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnectTimeout  time.Duration
+}
+
+// DB registra la conexión a Postgres con los nombres de variables de los deployments.
+func (l *Loader) DB() *DB {
+	c := &DB{}
+	l.String(&c.URL, "db-url", "DATABASE_URL", "", "full Postgres URL; overrides the db-* pieces")
+	l.String(&c.Host, "db-host", "DB_HOST", "localhost", "Postgres host")
+	l.Int(&c.Port, "db-port", "DB_PORT", 5432, "Postgres port")
+	l.String(&c.Name, "db-name", "DB_NAME", "", "database name")
+	l.String(&c.User, "db-user", "DB_USER", "", "database user")
+	l.String(&c.Password, "db-password", "DB_PASSWORD", "", "database password; prefer the env variable")
+	l.String(&c.SSLMode, "db-sslmode", "DB_SSLMODE", "disable", "Postgres sslmode")
+	l.Int(&c.MaxOpenConns, "db-max-open-conns", "DB_MAX_OPEN_CONNS", 10, "maximum open connections per replica")
+	l.Int(&c.MaxIdleConns, "db-max-idle-conns", "DB_MAX_IDLE_CONNS", 5, "maximum idle connections per replica")
+	l.Duration(&c.ConnMaxLifetime, "db-conn-max-lifetime", "DB_CONN_MAX_LIFETIME", 30*time.Minute, "maximum lifetime of a connection")
+	l.Duration(&c.ConnectTimeout, "db-connect-timeout", "DB_CONNECT_TIMEOUT", 5*time.Second, "timeout of the startup ping")
+	l.Check(c.validate)
+	return c
+}
+
+func (c *DB) validate() error {
+	var problems []string
+	if c.URL == "" {
+		if c.Host == "" {
+			problems = append(problems, "db-host is required")
+		}
+		if c.Port < 1 || c.Port > 65535 {
+			problems = append(problems, fmt.Sprintf("db-port must be between 1 and 65535, got %d", c.Port))
+		}
+		if c.Name == "" {
+			problems = append(problems, "db-name is required")
+		}
+		if c.User == "" {
+			problems = append(problems, "db-user is required")
+		}
+	}
+	if c.MaxOpenConns < 1 {
+		problems = append(problems, fmt.Sprintf("db-max-open-conns must be at least 1, got %d", c.MaxOpenConns))
+	}
+	if c.MaxIdleConns < 0 || c.MaxIdleConns > c.MaxOpenConns {
+		problems = append(problems, fmt.Sprintf("db-max-idle-conns must be between 0 and db-max-open-conns, got %d", c.MaxIdleConns))
+	}
+	if c.ConnMaxLifetime < 0 {
+		problems = append(problems, "db-conn-max-lifetime must not be negative")
+	}
+	if c.ConnectTimeout <= 0 {
+		problems = append(problems, "db-connect-timeout must be positive")
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("database: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// DSN es la URL de conexión; el usuario y la contraseña se escapan.
+func (c *DB) DSN() string {
+	if c.URL != "" {
+		return c.URL
+	}
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     c.Host + ":" + strconv.Itoa(c.Port),
+		Path:     "/" + c.Name,
+		RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+	}
+	return u.String()
+}
+
+// Open abre el pool con sus límites y comprueba la conexión. El driver "postgres" lo
+// registra el binario al importar lib/pq.
+func (c *DB) Open(ctx context.Context) (*sql.DB, error) {
+	db, err := sql.Open("postgres", c.DSN())
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(c.MaxOpenConns)
+	db.SetMaxIdleConns(c.MaxIdleConns)
+	db.SetConnMaxLifetime(c.ConnMaxLifetime)
+
+	ctx, cancel := context.WithTimeout(ctx, c.ConnectTimeout)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Peer es un servicio interno al que se conecta un cliente gRPC.
+type Peer struct {
+	Addr    string
+	Timeout time.Duration
+}
+
+// Peer registra -<name>-addr / <NAME>_URL y -<name>-timeout / <NAME>_TIMEOUT.
+func (l *Loader) Peer(name, defAddr string, defTimeout time.Duration) *Peer {
+	c := &Peer{}
+	env := strings.ToUpper(name)
+	l.String(&c.Addr, name+"-addr", env+"_URL", defAddr, name+" service address (host:port)")
+	l.Duration(&c.Timeout, name+"-timeout", env+"_TIMEOUT", defTimeout, "timeout of each call to the "+name+" service")
+	l.Check(func() error {
+		if c.Addr == "" {
+			return fmt.Errorf("%s-addr is required", name)
+		}
+		return nil
+	})
+	l.Check(Positive(name+"-timeout", &c.Timeout))
+	return c
+}
+
+// Target es el target de grpc.NewClient. Sin esquema se usa dns:///, que reparte las
+// llamadas entre los pods del servicio.
+func (c *Peer) Target() string {
+	if strings.Contains(c.Addr, "://") {
+		return c.Addr
+	}
+	return "dns:///" + c.Addr
+}
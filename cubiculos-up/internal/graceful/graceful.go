@@ -0,0 +1,69 @@
+// Package graceful detiene los servidores gRPC sin cortar las llamadas en curso cuando
+// Kubernetes manda SIGTERM (rolling updates, scale-down del HPA).
+package graceful
+
+import (
+	"context"
+	"log"
+	"net"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+)
+
+// SignalContext regresa un contexto que termina con SIGTERM o SIGINT. Una segunda señal
+// termina el proceso de inmediato.
+func SignalContext() context.Context {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		<-ctx.Done()
+		// Sin el handler, la siguiente señal usa el comportamiento default y mata el proceso.
+		stop()
+	}()
+	return ctx
+}
+
+// Serve atiende s en lis hasta que ctx termine. Entonces marca hs como NOT_SERVING,
+// espera delay para que Kubernetes saque el pod de los endpoints del Service (durante ese
+// tiempo siguen llegando llamadas y se atienden), y llama GracefulStop; si las llamadas
+// en curso no terminan en timeout, las corta con Stop. Regresa el error de Serve, o nil
+// si el servidor se detuvo por ctx.
+func Serve(ctx context.Context, s *grpc.Server, lis net.Listener, hs *health.Server, delay, timeout time.Duration) error {
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- s.Serve(lis) }()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("Shutting down: draining for %s", delay)
+	hs.Shutdown()
+	time.Sleep(delay)
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		log.Println("In-flight calls finished")
+	case <-time.After(timeout):
+		log.Printf("WARNING: calls still running after %s; closing them", timeout)
+		s.Stop()
+		<-stopped
+	}
+	return <-serveErr
+}
+
+// Close cierra c al apagar y registra el error si lo hay; what describe el recurso.
+func Close(what string, c interface{ Close() error }) {
+	if err := c.Close(); err != nil {
+		log.Printf("WARNING: closing %s: %v", what, err)
+	}
+}
@@ -0,0 +1,102 @@
+// Package grpcerr traduce errores de Postgres y de los servicios internos a status gRPC,
+// para que los clientes reciban códigos útiles (NotFound, AlreadyExists, ...) en lugar de
+// codes.Unknown con el texto crudo del driver.
+package grpcerr
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SQLSTATE de Postgres que se traducen a un código específico.
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+	checkViolation      = "23514"
+	notNullViolation    = "23502"
+	exclusionViolation  = "23P01"
+	serializationFail   = "40001"
+	deadlockDetected    = "40P01"
+	queryCanceled       = "57014"
+	lockNotAvailable    = "55P03"
+)
+
+// DB convierte un error de database/sql en un status gRPC. what describe el recurso
+// afectado ("cubicle C-101") y se usa en el mensaje. Los errores que ya son status gRPC se
+// regresan sin cambios; los que no tienen traducción se registran en el log y se reportan
+// como Internal sin exponer el texto de Postgres.
+func DB(err error, what string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Errorf(codes.NotFound, "%s not found", what)
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Errorf(codes.DeadlineExceeded, "%s: database deadline exceeded", what)
+	case errors.Is(err, context.Canceled):
+		return status.Errorf(codes.Canceled, "%s: request canceled", what)
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone):
+		return status.Errorf(codes.Unavailable, "%s: database unavailable", what)
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case uniqueViolation, exclusionViolation:
+			return status.Errorf(codes.AlreadyExists, "%s already exists", what)
+		case foreignKeyViolation:
+			return status.Errorf(codes.FailedPrecondition, "%s references a record that does not exist", what)
+		case checkViolation, notNullViolation:
+			return status.Errorf(codes.InvalidArgument, "%s violates constraint %s", what, pqErr.Constraint)
+		case serializationFail, deadlockDetected, lockNotAvailable:
+			return status.Errorf(codes.Aborted, "%s: concurrent update, retry", what)
+		case queryCanceled:
+			return status.Errorf(codes.DeadlineExceeded, "%s: database deadline exceeded", what)
+		}
+		switch pqErr.Code.Class() {
+		case "22": // data_exception
+			return status.Errorf(codes.InvalidArgument, "%s: invalid value", what)
+		case "08", "57": // connection_exception, operator_intervention
+			return status.Errorf(codes.Unavailable, "%s: database unavailable", what)
+		case "53": // insufficient_resources
+			return status.Errorf(codes.ResourceExhausted, "%s: database out of resources", what)
+		}
+	}
+
+	log.Printf("database error (%s): %v", what, err)
+	return status.Errorf(codes.Internal, "%s: internal database error", what)
+}
+
+// Upstream envuelve el error de una llamada a otro servicio conservando su código y sus
+// detalles; solo antepone el nombre del servicio al mensaje. Un error que no es status
+// (p. ej. la conexión nunca se estableció) se reporta como Unavailable.
+func Upstream(err error, service string) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return status.Errorf(codes.Unavailable, "%s service: %v", service, err)
+	}
+
+	p := st.Proto()
+	if !strings.HasPrefix(p.Message, service+" service: ") {
+		p.Message = fmt.Sprintf("%s service: %s", service, p.Message)
+	}
+	return status.FromProto(p).Err()
+}
@@ -0,0 +1,134 @@
+// Package healthcheck publica el estado de los servicios con el servicio estándar
+// grpc.health.v1. El servicio "" (el que consultan las readiness probes) refleja las
+// dependencias de cada binario: Postgres en metadata y reservation, y la conexión con los
+// servicios internos en cubicle. El servicio "liveness" solo indica que el proceso
+// responde, para que Kubernetes no reinicie un pod porque se cayó la base de datos.
+package healthcheck
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Liveness es el nombre del servicio que usan las liveness probes.
+const Liveness = "liveness"
+
+// Check revisa una dependencia; un error marca el servicio como NOT_SERVING.
+type Check func(ctx context.Context) error
+
+// NewServer crea el servidor de health. services son los nombres de los servicios gRPC del
+// binario (p. ej. "cubicles.MetadataService"), que siguen el mismo estado que "".
+// Todo empieza en NOT_SERVING hasta la primera revisión de Watch.
+func NewServer(services ...string) *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	for _, service := range services {
+		hs.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	hs.SetServingStatus(Liveness, healthpb.HealthCheckResponse_SERVING)
+	return hs
+}
+
+// Watch corre checks cada interval hasta que ctx termine y actualiza "" y services.
+// Cada revisión tiene un plazo de interval. Los cambios de estado se registran en el log.
+func Watch(ctx context.Context, hs *health.Server, interval time.Duration, checks []Check, services ...string) {
+	serving := false
+	first := true
+	update := func() {
+		checkCtx, cancel := context.WithTimeout(ctx, interval)
+		defer cancel()
+
+		var failure error
+		for _, check := range checks {
+			if failure = check(checkCtx); failure != nil {
+				break
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		now := failure == nil
+		if now == serving && !first {
+			return
+		}
+		first = false
+		serving = now
+
+		status := healthpb.HealthCheckResponse_SERVING
+		if !now {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			log.Printf("WARNING: not ready: %v", failure)
+		} else {
+			log.Println("Ready to serve")
+		}
+		hs.SetServingStatus("", status)
+		for _, service := range services {
+			hs.SetServingStatus(service, status)
+		}
+	}
+
+	update()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			update()
+		}
+	}
+}
+
+// DB revisa que Postgres responda.
+func DB(db *sql.DB) Check {
+	return func(ctx context.Context) error {
+		if err := db.PingContext(ctx); err != nil {
+			return fmt.Errorf("database: %w", err)
+		}
+		return nil
+	}
+}
+
+// Conn revisa que la conexión con el servicio name no esté fallando. Una conexión
+// inactiva (IDLE) cuenta como sana; se le pide reconectar para que la siguiente revisión
+// vea su estado real.
+func Conn(name string, cc *grpc.ClientConn) Check {
+	return func(context.Context) error {
+		switch state := cc.GetState(); state {
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return fmt.Errorf("%s connection is %s", name, state)
+		case connectivity.Idle:
+			cc.Connect()
+		}
+		return nil
+	}
+}
+
+// Serve atiende solo el servicio de health, sin TLS ni autenticación, en addr. Es el
+// puerto de las probes de Kubernetes, que no pueden presentar certificados de cliente.
+// Regresa el servidor para poder detenerlo.
+func Serve(addr string, hs *health.Server) (*grpc.Server, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := grpc.NewServer()
+	healthpb.RegisterHealthServer(s, hs)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			log.Printf("health server stopped: %v", err)
+		}
+	}()
+	return s, nil
+}
@@ -0,0 +1,367 @@
+// Package migrate aplica las migraciones versionadas del esquema de Postgres que comparten
+// los servicios metadata y reservation.
+//
+// Las migraciones viven en sql/ como pares NNNN_nombre.up.sql / NNNN_nombre.down.sql y se
+// compilan dentro del binario. Cada una corre en su propia transacción, su checksum queda
+// registrado en schema_migrations y un advisory lock garantiza que solo una réplica migre
+// a la vez.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// lockKey identifica el advisory lock de Postgres que toman las migraciones.
+const lockKey int64 = 0x6375_6269_6d69_67 // "cubimig"
+
+// Migration es una versión del esquema.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Applied describe una migración registrada en schema_migrations.
+type Applied struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Runner aplica o revierte migraciones sobre una base de datos.
+type Runner struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New carga las migraciones embebidas.
+func New(db *sql.DB) (*Runner, error) {
+	migrations, err := load(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Runner{db: db, migrations: migrations}, nil
+}
+
+// load lee los pares up/down de fsys y los regresa ordenados por versión.
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.Glob(fsys, "sql/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, p := range entries {
+		base := path.Base(p)
+		var direction string
+		switch {
+		case strings.HasSuffix(base, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(base, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("migration %s: expected .up.sql or .down.sql suffix", base)
+		}
+
+		stem := strings.TrimSuffix(base, "."+direction+".sql")
+		rawVersion, name, ok := strings.Cut(stem, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s: expected NNNN_name prefix", base)
+		}
+		version, err := strconv.ParseInt(rawVersion, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: invalid version: %v", base, err)
+		}
+
+		body, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, m.Name, name)
+		}
+
+		if direction == "up" {
+			m.Up = string(body)
+			sum := sha256.Sum256(body)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no .up.sql", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up aplica todas las migraciones pendientes y regresa cuántas se aplicaron.
+func (r *Runner) Up(ctx context.Context) (int, error) {
+	count := 0
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := r.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range r.migrations {
+			if _, ok := applied[m.Version]; ok {
+				continue
+			}
+			log.Printf("migrate: applying %04d_%s", m.Version, m.Name)
+			if err := r.apply(ctx, conn, m.Up, func(tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx, `
+					INSERT INTO schema_migrations (version, name, checksum)
+					VALUES ($1, $2, $3)
+				`, m.Version, m.Name, m.Checksum)
+				return err
+			}); err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down revierte las últimas steps migraciones aplicadas y regresa cuántas se revirtieron.
+func (r *Runner) Down(ctx context.Context, steps int) (int, error) {
+	count := 0
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := r.verify(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(r.migrations) - 1; i >= 0 && count < steps; i-- {
+			m := r.migrations[i]
+			if _, ok := applied[m.Version]; !ok {
+				continue
+			}
+			if m.Down == "" {
+				return fmt.Errorf("migration %04d_%s has no .down.sql", m.Version, m.Name)
+			}
+			log.Printf("migrate: reverting %04d_%s", m.Version, m.Name)
+			if err := r.apply(ctx, conn, m.Down, func(tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, m.Version)
+				return err
+			}); err != nil {
+				return fmt.Errorf("revert %04d_%s: %w", m.Version, m.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Status regresa las migraciones registradas en la base de datos.
+func (r *Runner) Status(ctx context.Context) ([]Applied, error) {
+	var out []Applied
+	err := r.withLock(ctx, func(conn *sql.Conn) error {
+		rows, err := conn.QueryContext(ctx, `
+			SELECT version, name, checksum, applied_at
+			FROM schema_migrations
+			ORDER BY version
+		`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var a Applied
+			if err := rows.Scan(&a.Version, &a.Name, &a.Checksum, &a.AppliedAt); err != nil {
+				return err
+			}
+			out = append(out, a)
+		}
+		return rows.Err()
+	})
+	return out, err
+}
+
+// withLock toma el advisory lock en una conexión dedicada, asegura que exista la tabla
+// schema_migrations y ejecuta fn. Las demás réplicas esperan hasta que se libere.
+func (r *Runner) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Se usa un contexto nuevo para liberar el lock aunque ctx ya se haya cancelado.
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey); err != nil {
+			log.Printf("migrate: release lock: %v", err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)
+	`); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// verify compara las migraciones aplicadas contra las embebidas. Un checksum distinto es
+// un error (alguien editó una migración ya aplicada); una versión desconocida solo se
+// reporta, porque durante un rolling update conviven binarios viejos con un esquema nuevo.
+func (r *Runner) verify(ctx context.Context, conn *sql.Conn) (map[int64]string, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, checksum FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int64]string{}
+	for rows.Next() {
+		var (
+			version  int64
+			checksum string
+		)
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, err
+		}
+		applied[version] = checksum
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	known := map[int64]bool{}
+	for _, m := range r.migrations {
+		known[m.Version] = true
+		if checksum, ok := applied[m.Version]; ok && checksum != m.Checksum {
+			return nil, fmt.Errorf("migration %04d_%s was modified after being applied (checksum %s, expected %s)",
+				m.Version, m.Name, checksum, m.Checksum)
+		}
+	}
+	for version := range applied {
+		if !known[version] {
+			log.Printf("migrate: WARNING: database has migration %04d which this binary does not know", version)
+		}
+	}
+	return applied, nil
+}
+
+// apply ejecuta script y record dentro de una misma transacción.
+func (r *Runner) apply(ctx context.Context, conn *sql.Conn, script string, record func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if err := record(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RunCommand implementa el subcomando "migrate" de los binarios:
+//
+//	migrate up        aplica las migraciones pendientes (default)
+//	migrate down [n]  revierte las últimas n migraciones (default 1)
+//	migrate status    lista las migraciones aplicadas y pendientes
+func RunCommand(ctx context.Context, db *sql.DB, args []string, w io.Writer) error {
+	r, err := New(db)
+	if err != nil {
+		return err
+	}
+
+	cmd := "up"
+	if len(args) > 0 {
+		cmd = args[0]
+	}
+
+	switch cmd {
+	case "up":
+		n, err := r.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "applied %d migration(s)\n", n)
+		return nil
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+		n, err := r.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "reverted %d migration(s)\n", n)
+		return nil
+
+	case "status":
+		applied, err := r.Status(ctx)
+		if err != nil {
+			return err
+		}
+		done := map[int64]Applied{}
+		for _, a := range applied {
+			done[a.Version] = a
+		}
+		for _, m := range r.migrations {
+			if a, ok := done[m.Version]; ok {
+				fmt.Fprintf(w, "%04d_%s\tapplied %s\n", m.Version, m.Name, a.AppliedAt.Format(time.RFC3339))
+			} else {
+				fmt.Fprintf(w, "%04d_%s\tpending\n", m.Version, m.Name)
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown migrate command %q (want up, down or status)", cmd)
+	}
+}
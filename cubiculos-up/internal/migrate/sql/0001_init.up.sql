@@ -1,3 +1,5 @@
+-- Esquema inicial (antes k8s/postgres/migrations/00_init.sql). Usa IF NOT EXISTS para
+-- adoptar bases que ya se habían creado con el ConfigMap de Postgres.
 CREATE TABLE IF NOT EXISTS metadata (
     id VARCHAR PRIMARY KEY,
     name TEXT,
@@ -0,0 +1,21 @@
+ALTER TABLE reservations
+    DROP CONSTRAINT IF EXISTS reservations_no_overlap,
+    DROP COLUMN IF EXISTS period,
+    DROP CONSTRAINT IF EXISTS reservations_period_check,
+    DROP CONSTRAINT IF EXISTS reservations_cubicle_id_fkey,
+    DROP CONSTRAINT IF EXISTS reservations_record_id_key;
+
+ALTER TABLE reservations
+    ALTER COLUMN status DROP DEFAULT,
+    ALTER COLUMN status DROP NOT NULL,
+    ALTER COLUMN status TYPE TEXT USING status::TEXT,
+    ALTER COLUMN start_time DROP NOT NULL,
+    ALTER COLUMN end_time DROP NOT NULL,
+    ALTER COLUMN start_time TYPE TIMESTAMP USING start_time AT TIME ZONE 'UTC',
+    ALTER COLUMN end_time TYPE TIMESTAMP USING end_time AT TIME ZONE 'UTC',
+    ALTER COLUMN record_id DROP NOT NULL;
+
+UPDATE reservations SET record_type = cubicle_id;
+ALTER TABLE reservations DROP COLUMN IF EXISTS cubicle_id;
+
+DROP TYPE IF EXISTS reservation_status;
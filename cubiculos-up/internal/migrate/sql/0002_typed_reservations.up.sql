@@ -0,0 +1,81 @@
+-- Esquema tipado para reservations. Parte de la tabla de 0001_init (el antiguo
+-- k8s/postgres/migrations/00_init.sql), donde el cubículo se guardaba en record_type, los
+-- horarios eran TIMESTAMP sin zona y status era texto libre:
+--   * cubicle_id dedicado (copiado de record_type) con llave foránea a metadata(id)
+--   * record_id único y obligatorio
+--   * start_time / end_time como TIMESTAMPTZ (los valores existentes se guardaron en UTC)
+--   * status como enum reservation_status
+--   * columna period (tstzrange) con restricción de exclusión para reservas CONFIRMED
+--
+-- Las filas que no cumplen las restricciones nuevas se cancelan o se corrigen antes de
+-- agregarlas, para que la migración no aborte sobre datos heredados.
+
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TYPE reservation_status AS ENUM ('PENDING', 'CONFIRMED', 'CANCELLED');
+
+-- record_id: rellena los vacíos y desambigua duplicados antes de exigir unicidad.
+UPDATE reservations SET record_id = 'legacy-' || id WHERE record_id IS NULL OR record_id = '';
+UPDATE reservations r
+SET record_id = r.record_id || '-' || r.id
+WHERE EXISTS (
+    SELECT 1 FROM reservations o WHERE o.record_id = r.record_id AND o.id < r.id
+);
+ALTER TABLE reservations ALTER COLUMN record_id SET NOT NULL;
+ALTER TABLE reservations ADD CONSTRAINT reservations_record_id_key UNIQUE (record_id);
+
+-- cubicle_id: se copia desde record_type, que es donde se guardaba el cubículo.
+ALTER TABLE reservations ADD COLUMN cubicle_id TEXT;
+UPDATE reservations SET cubicle_id = record_type;
+ALTER TABLE reservations ALTER COLUMN cubicle_id SET NOT NULL;
+-- NOT VALID: las filas históricas que apunten a cubículos inexistentes se conservan,
+-- pero toda fila nueva o modificada debe referenciar un cubículo real.
+ALTER TABLE reservations
+    ADD CONSTRAINT reservations_cubicle_id_fkey
+    FOREIGN KEY (cubicle_id) REFERENCES metadata (id) NOT VALID;
+
+-- Timestamps con zona horaria.
+ALTER TABLE reservations
+    ALTER COLUMN start_time TYPE TIMESTAMPTZ USING start_time AT TIME ZONE 'UTC',
+    ALTER COLUMN end_time TYPE TIMESTAMPTZ USING end_time AT TIME ZONE 'UTC';
+-- Filas sin horario o con el fin antes del inicio: se cancelan con un periodo
+-- centinela de un segundo (se conserva el extremo conocido, o epoch si no hay
+-- ninguno) para que las restricciones siguientes no aborten la migración.
+UPDATE reservations
+SET status = 'CANCELLED',
+    start_time = COALESCE(start_time, end_time, TIMESTAMPTZ 'epoch'),
+    end_time = COALESCE(start_time, end_time, TIMESTAMPTZ 'epoch') + INTERVAL '1 second'
+WHERE start_time IS NULL OR end_time IS NULL OR start_time >= end_time;
+ALTER TABLE reservations
+    ALTER COLUMN start_time SET NOT NULL,
+    ALTER COLUMN end_time SET NOT NULL,
+    ADD CONSTRAINT reservations_period_check CHECK (start_time < end_time);
+
+-- status como enum; cualquier valor desconocido se considera cancelado.
+UPDATE reservations
+SET status = 'CANCELLED'
+WHERE status IS NULL OR status NOT IN ('PENDING', 'CONFIRMED', 'CANCELLED');
+ALTER TABLE reservations
+    ALTER COLUMN status TYPE reservation_status USING status::reservation_status,
+    ALTER COLUMN status SET DEFAULT 'CONFIRMED',
+    ALTER COLUMN status SET NOT NULL;
+
+ALTER TABLE reservations
+    ADD COLUMN period TSTZRANGE GENERATED ALWAYS AS (tstzrange(start_time, end_time, '[)')) STORED;
+
+-- Reservas CONFIRMED que ya se traslapaban: se conserva la más antigua y se cancelan las demás.
+UPDATE reservations r
+SET status = 'CANCELLED'
+WHERE r.status = 'CONFIRMED'
+  AND EXISTS (
+    SELECT 1 FROM reservations o
+    WHERE o.cubicle_id = r.cubicle_id
+      AND o.status = 'CONFIRMED'
+      AND o.id < r.id
+      AND o.period && r.period
+  );
+
+ALTER TABLE reservations
+    ADD CONSTRAINT reservations_no_overlap
+    EXCLUDE USING gist (cubicle_id WITH =, period WITH &&)
+    WHERE (status = 'CONFIRMED');
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS metadata_name_prefix_idx;
+DROP INDEX IF EXISTS metadata_capacity_id_idx;
+DROP INDEX IF EXISTS metadata_location_name_id_idx;
+DROP INDEX IF EXISTS metadata_name_id_idx;
+
+ALTER TABLE metadata
+    ALTER COLUMN name DROP NOT NULL,
+    ALTER COLUMN name DROP DEFAULT,
+    ALTER COLUMN location DROP NOT NULL,
+    ALTER COLUMN location DROP DEFAULT,
+    ALTER COLUMN capacity DROP NOT NULL,
+    ALTER COLUMN capacity DROP DEFAULT;
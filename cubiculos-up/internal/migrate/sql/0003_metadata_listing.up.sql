@@ -0,0 +1,19 @@
+-- ListMetadata ordena y filtra por name, location y capacity: se vuelven obligatorios
+-- (GetMetadata ya fallaba al escanear NULLs) y se indexan para la paginación por llave.
+UPDATE metadata SET name = '' WHERE name IS NULL;
+UPDATE metadata SET location = '' WHERE location IS NULL;
+UPDATE metadata SET capacity = 0 WHERE capacity IS NULL;
+
+ALTER TABLE metadata
+    ALTER COLUMN name SET DEFAULT '',
+    ALTER COLUMN name SET NOT NULL,
+    ALTER COLUMN location SET DEFAULT '',
+    ALTER COLUMN location SET NOT NULL,
+    ALTER COLUMN capacity SET DEFAULT 0,
+    ALTER COLUMN capacity SET NOT NULL;
+
+CREATE INDEX metadata_name_id_idx ON metadata (name, id);
+CREATE INDEX metadata_location_name_id_idx ON metadata (location, name, id);
+CREATE INDEX metadata_capacity_id_idx ON metadata (capacity, id);
+-- Búsqueda por prefijo (name LIKE 'abc%') independiente de la collation.
+CREATE INDEX metadata_name_prefix_idx ON metadata (name text_pattern_ops);
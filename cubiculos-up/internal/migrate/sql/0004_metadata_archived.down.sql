@@ -0,0 +1,3 @@
+ALTER TABLE metadata
+    DROP COLUMN IF EXISTS archived_at,
+    DROP COLUMN IF EXISTS archived;
@@ -0,0 +1,4 @@
+-- Soft-delete de cubículos (DeleteMetadata).
+ALTER TABLE metadata
+    ADD COLUMN archived BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN archived_at TIMESTAMPTZ;
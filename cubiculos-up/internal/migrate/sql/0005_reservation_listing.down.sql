@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS reservations_start_idx;
+DROP INDEX IF EXISTS reservations_user_start_idx;
+DROP INDEX IF EXISTS reservations_cubicle_start_idx;
@@ -0,0 +1,4 @@
+-- Índices para ListReservations (agenda de un cubículo y "mis reservas").
+CREATE INDEX reservations_cubicle_start_idx ON reservations (cubicle_id, start_time, record_id);
+CREATE INDEX reservations_user_start_idx ON reservations (user_id, start_time, record_id);
+CREATE INDEX reservations_start_idx ON reservations (start_time, record_id);
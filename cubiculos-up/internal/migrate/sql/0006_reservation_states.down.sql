@@ -0,0 +1,19 @@
+-- Postgres no permite quitar valores de un enum: se recrea el tipo con los valores
+-- originales y los estados nuevos se mapean al más cercano.
+ALTER TABLE reservations DROP CONSTRAINT IF EXISTS reservations_no_overlap;
+ALTER TABLE reservations ALTER COLUMN status DROP DEFAULT;
+
+UPDATE reservations SET status = 'CONFIRMED' WHERE status IN ('CHECKED_IN', 'COMPLETED');
+UPDATE reservations SET status = 'CANCELLED' WHERE status = 'NO_SHOW';
+
+ALTER TYPE reservation_status RENAME TO reservation_status_old;
+CREATE TYPE reservation_status AS ENUM ('PENDING', 'CONFIRMED', 'CANCELLED');
+ALTER TABLE reservations
+    ALTER COLUMN status TYPE reservation_status USING status::TEXT::reservation_status,
+    ALTER COLUMN status SET DEFAULT 'CONFIRMED';
+DROP TYPE reservation_status_old;
+
+ALTER TABLE reservations
+    ADD CONSTRAINT reservations_no_overlap
+    EXCLUDE USING gist (cubicle_id WITH =, period WITH &&)
+    WHERE (status = 'CONFIRMED');
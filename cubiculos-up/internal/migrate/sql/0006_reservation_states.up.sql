@@ -0,0 +1,5 @@
+-- Estados del ciclo de vida de una reserva. Van en su propia migración porque un valor
+-- nuevo de un enum no puede usarse en la misma transacción que lo agrega.
+ALTER TYPE reservation_status ADD VALUE IF NOT EXISTS 'CHECKED_IN';
+ALTER TYPE reservation_status ADD VALUE IF NOT EXISTS 'COMPLETED';
+ALTER TYPE reservation_status ADD VALUE IF NOT EXISTS 'NO_SHOW';
@@ -0,0 +1,14 @@
+ALTER TABLE reservations DROP CONSTRAINT reservations_no_overlap;
+ALTER TABLE reservations
+    ADD CONSTRAINT reservations_no_overlap
+    EXCLUDE USING gist (cubicle_id WITH =, period WITH &&)
+    WHERE (status = 'CONFIRMED');
+
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS cancel_reason,
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS completed_at,
+    DROP COLUMN IF EXISTS checked_in_at,
+    DROP COLUMN IF EXISTS confirmed_at,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,32 @@
+-- Ciclo de vida: PENDING -> CONFIRMED -> CHECKED_IN -> COMPLETED, con CANCELLED y NO_SHOW.
+-- Cancelar ya no borra la fila; se guardan las marcas de tiempo de cada transición.
+ALTER TABLE reservations
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN confirmed_at TIMESTAMPTZ,
+    ADD COLUMN checked_in_at TIMESTAMPTZ,
+    ADD COLUMN completed_at TIMESTAMPTZ,
+    ADD COLUMN cancelled_at TIMESTAMPTZ,
+    ADD COLUMN cancel_reason TEXT;
+
+-- Ahora ocupan el cubículo todos los estados activos, no solo CONFIRMED.
+ALTER TABLE reservations DROP CONSTRAINT reservations_no_overlap;
+
+-- Las reservas PENDING heredadas nunca pasaron por la restricción anterior y pueden
+-- traslaparse entre sí o con una CONFIRMED: igual que en 0002, se conserva la más antigua
+-- y se cancelan las demás.
+UPDATE reservations r
+SET status = 'CANCELLED', cancelled_at = now(), updated_at = now()
+WHERE r.status IN ('PENDING', 'CONFIRMED')
+  AND EXISTS (
+    SELECT 1 FROM reservations o
+    WHERE o.cubicle_id = r.cubicle_id
+      AND o.status IN ('PENDING', 'CONFIRMED')
+      AND o.id < r.id
+      AND o.period && r.period
+  );
+
+ALTER TABLE reservations
+    ADD CONSTRAINT reservations_no_overlap
+    EXCLUDE USING gist (cubicle_id WITH =, period WITH &&)
+    WHERE (status IN ('PENDING', 'CONFIRMED', 'CHECKED_IN'));
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS closures;
+DROP TABLE IF EXISTS opening_hours;
+DROP TABLE IF EXISTS location_calendars;
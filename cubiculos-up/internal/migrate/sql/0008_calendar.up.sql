@@ -0,0 +1,33 @@
+-- Calendario: horario semanal por ubicación y cierres de una ubicación (días festivos) o
+-- de un cubículo (mantenimiento). Una ubicación sin fila en location_calendars está
+-- abierta siempre.
+CREATE TABLE location_calendars (
+    location TEXT PRIMARY KEY,
+    time_zone TEXT NOT NULL DEFAULT 'UTC',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Rangos de apertura en minutos desde la medianoche local; weekday 0 = domingo.
+CREATE TABLE opening_hours (
+    location TEXT NOT NULL REFERENCES location_calendars (location) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    open_minute INT NOT NULL,
+    close_minute INT NOT NULL,
+    PRIMARY KEY (location, weekday, open_minute),
+    CONSTRAINT opening_hours_range CHECK (0 <= open_minute AND open_minute < close_minute AND close_minute <= 1440)
+);
+
+CREATE TABLE closures (
+    id TEXT PRIMARY KEY,
+    location TEXT,
+    cubicle_id VARCHAR REFERENCES metadata (id),
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT closures_target CHECK ((location IS NULL) <> (cubicle_id IS NULL)),
+    CONSTRAINT closures_interval CHECK (start_time < end_time)
+);
+
+CREATE INDEX closures_location_idx ON closures (location, start_time) WHERE location IS NOT NULL;
+CREATE INDEX closures_cubicle_idx ON closures (cubicle_id, start_time) WHERE cubicle_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS reservations_series_start_idx;
+ALTER TABLE reservations DROP COLUMN IF EXISTS series_id;
+DROP TABLE IF EXISTS reservation_series;
@@ -0,0 +1,13 @@
+-- Reservas recurrentes: cada serie guarda su regla y sus ocurrencias apuntan a ella.
+CREATE TABLE reservation_series (
+    id TEXT PRIMARY KEY,
+    cubicle_id VARCHAR NOT NULL REFERENCES metadata (id),
+    user_id TEXT NOT NULL,
+    recurrence TEXT NOT NULL,
+    time_zone TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE reservations ADD COLUMN series_id TEXT REFERENCES reservation_series (id);
+
+CREATE INDEX reservations_series_start_idx ON reservations (series_id, start_time) WHERE series_id IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS waitlist_entries;
+DROP TYPE IF EXISTS waitlist_status;
@@ -0,0 +1,23 @@
+-- Lista de espera por cubículo e intervalo. Una oferta es una reserva PENDING
+-- (offer_record_id) que vence en offer_expires_at si no se acepta.
+CREATE TYPE waitlist_status AS ENUM ('WAITING', 'OFFERED', 'ACCEPTED', 'EXPIRED', 'LEFT');
+
+CREATE TABLE waitlist_entries (
+    id TEXT PRIMARY KEY,
+    cubicle_id VARCHAR NOT NULL REFERENCES metadata (id),
+    user_id TEXT NOT NULL,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    status waitlist_status NOT NULL DEFAULT 'WAITING',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    offer_record_id TEXT REFERENCES reservations (record_id),
+    offered_at TIMESTAMPTZ,
+    offer_expires_at TIMESTAMPTZ,
+    CONSTRAINT waitlist_entries_interval CHECK (start_time < end_time)
+);
+
+-- Fila de cada cubículo, y ofertas por vencer para el barrido.
+CREATE INDEX waitlist_entries_queue_idx ON waitlist_entries (cubicle_id, created_at, id) WHERE status = 'WAITING';
+CREATE INDEX waitlist_entries_offers_idx ON waitlist_entries (offer_expires_at) WHERE status = 'OFFERED';
+CREATE INDEX waitlist_entries_user_idx ON waitlist_entries (user_id, created_at);
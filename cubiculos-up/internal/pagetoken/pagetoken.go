@@ -0,0 +1,61 @@
+// Package pagetoken arma los cursores opacos de los listados paginados. Cada token guarda,
+// además del cursor (la llave de la última fila entregada), un hash de los filtros con los
+// que se emitió, para rechazar un token que se reutiliza con otra consulta en lugar de
+// regresar en silencio una página que no corresponde.
+package pagetoken
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var (
+	// ErrMalformed indica que el token no se puede leer.
+	ErrMalformed = errors.New("invalid pageToken")
+	// ErrFiltersChanged indica que el token se emitió para otros filtros u otro orden.
+	ErrFiltersChanged = errors.New("pageToken was issued for different filters; repeat the filters of the first page")
+)
+
+// token es lo que viaja, en JSON y base64, dentro de nextPageToken.
+type token struct {
+	Filters string          `json:"f"`
+	Cursor  json.RawMessage `json:"c"`
+}
+
+// Encode regresa el token de cursor para la consulta descrita por filters. filters son los
+// valores que definen el resultado (filtros y orden, no el tamaño de página), en el mismo
+// orden en que se pasarán a Decode.
+func Encode(cursor any, filters ...any) string {
+	c, _ := json.Marshal(cursor)
+	raw, _ := json.Marshal(token{Filters: hash(filters), Cursor: c})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// Decode lee s en cursor. Regresa ErrMalformed si s no es un token, o ErrFiltersChanged si
+// se emitió con filtros distintos de filters.
+func Decode(s string, cursor any, filters ...any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return ErrMalformed
+	}
+	var t token
+	if err := json.Unmarshal(raw, &t); err != nil || len(t.Cursor) == 0 {
+		return ErrMalformed
+	}
+	if t.Filters != hash(filters) {
+		return ErrFiltersChanged
+	}
+	if err := json.Unmarshal(t.Cursor, cursor); err != nil {
+		return ErrMalformed
+	}
+	return nil
+}
+
+// hash resume los filtros; 12 bytes bastan para distinguir consultas, no es una firma.
+func hash(filters []any) string {
+	raw, _ := json.Marshal(filters)
+	sum := sha256.Sum256(raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
@@ -0,0 +1,47 @@
+package pagetoken
+
+import (
+	"errors"
+	"testing"
+)
+
+type cursor struct {
+	Key string `json:"k"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	s := Encode(cursor{Key: "r-42"}, "C-101", 3, []string{"CONFIRMED"})
+
+	var got cursor
+	if err := Decode(s, &got, "C-101", 3, []string{"CONFIRMED"}); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got.Key != "r-42" {
+		t.Errorf("cursor = %q, want r-42", got.Key)
+	}
+}
+
+func TestDecodeRejects(t *testing.T) {
+	valid := Encode(cursor{Key: "r-42"}, "C-101", 3)
+
+	tests := []struct {
+		name    string
+		token   string
+		filters []any
+		want    error
+	}{
+		{"other filter value", valid, []any{"C-102", 3}, ErrFiltersChanged},
+		{"missing filter", valid, []any{"C-101"}, ErrFiltersChanged},
+		{"not base64", "%%%", []any{"C-101", 3}, ErrMalformed},
+		{"not json", "bm90LWpzb24", []any{"C-101", 3}, ErrMalformed},
+		{"empty", "", []any{"C-101", 3}, ErrMalformed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got cursor
+			if err := Decode(tt.token, &got, tt.filters...); !errors.Is(err, tt.want) {
+				t.Errorf("Decode = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
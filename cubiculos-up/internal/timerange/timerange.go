@@ -0,0 +1,111 @@
+// Package timerange tiene el álgebra de intervalos de tiempo que comparten metadata (horario
+// y cierres de los cubículos) y reservation (huecos libres y disponibilidad), y el formato
+// "HH:MM" de las horas del día.
+package timerange
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interval es un intervalo semiabierto [Start, End).
+type Interval struct {
+	Start, End time.Time
+}
+
+// Empty indica si el intervalo no contiene ningún instante.
+func (i Interval) Empty() bool {
+	return !i.Start.Before(i.End)
+}
+
+// Clip regresa la parte de i dentro de window; puede quedar vacía.
+func (i Interval) Clip(window Interval) Interval {
+	if window.Start.After(i.Start) {
+		i.Start = window.Start
+	}
+	if window.End.Before(i.End) {
+		i.End = window.End
+	}
+	return i
+}
+
+// Append agrega i al final de list, que está ordenada, uniendo i con el último intervalo
+// si se tocan o se traslapan. Los intervalos vacíos se descartan.
+func Append(list []Interval, i Interval) []Interval {
+	if i.Empty() {
+		return list
+	}
+	if n := len(list); n > 0 && !list[n-1].End.Before(i.Start) {
+		if i.End.After(list[n-1].End) {
+			list[n-1].End = i.End
+		}
+		return list
+	}
+	return append(list, i)
+}
+
+// Subtract regresa, en orden, las partes de from (ordenados y disjuntos) que no cubre
+// ningún intervalo de remove. remove puede venir desordenado y con traslapes.
+func Subtract(from, remove []Interval) []Interval {
+	sorted := append([]Interval(nil), remove...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	var out []Interval
+	for _, f := range from {
+		cursor := f.Start
+		for _, r := range sorted {
+			if !r.Start.Before(f.End) {
+				break
+			}
+			if !r.End.After(cursor) {
+				continue
+			}
+			if r.Start.After(cursor) {
+				out = append(out, Interval{cursor, r.Start})
+			}
+			cursor = r.End
+		}
+		if cursor.Before(f.End) {
+			out = append(out, Interval{cursor, f.End})
+		}
+	}
+	return out
+}
+
+// Intersect regresa la intersección de dos listas ordenadas de intervalos disjuntos.
+func Intersect(a, b []Interval) []Interval {
+	var out []Interval
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if c := a[i].Clip(b[j]); !c.Empty() {
+			out = append(out, c)
+		}
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return out
+}
+
+// ParseClock interpreta "HH:MM" como minutos desde la medianoche; acepta "24:00".
+func ParseClock(s string) (int, error) {
+	hh, mm, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, fmt.Errorf("expected HH:MM, got %q", s)
+	}
+	h, errH := strconv.Atoi(hh)
+	m, errM := strconv.Atoi(mm)
+	if errH != nil || errM != nil || h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("expected HH:MM, got %q", s)
+	}
+	return h*60 + m, nil
+}
+
+// FormatClock es el inverso de ParseClock.
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,104 @@
+package timerange
+
+import (
+	"testing"
+	"time"
+)
+
+var day = time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+// span(h1, m1, h2, m2) es [h1:m1, h2:m2) del día de prueba.
+func span(fromH, fromM, toH, toM int) Interval {
+	at := func(h, m int) time.Time {
+		return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
+	}
+	return Interval{Start: at(fromH, fromM), End: at(toH, toM)}
+}
+
+func equal(a, b []Interval) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Start.Equal(b[i].Start) || !a[i].End.Equal(b[i].End) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSubtract(t *testing.T) {
+	window := []Interval{span(8, 0, 20, 0)}
+	tests := []struct {
+		name   string
+		from   []Interval
+		remove []Interval
+		want   []Interval
+	}{
+		{"nothing to remove", window, nil, window},
+		{"back-to-back", window, []Interval{span(10, 0, 11, 0), span(11, 0, 12, 0)},
+			[]Interval{span(8, 0, 10, 0), span(12, 0, 20, 0)}},
+		{"overlapping, unordered", window, []Interval{span(10, 30, 12, 0), span(10, 0, 11, 0), span(10, 15, 10, 45)},
+			[]Interval{span(8, 0, 10, 0), span(12, 0, 20, 0)}},
+		{"only in the future", window, []Interval{span(15, 0, 16, 0)},
+			[]Interval{span(8, 0, 15, 0), span(16, 0, 20, 0)}},
+		{"started before", window, []Interval{span(7, 0, 9, 0)}, []Interval{span(9, 0, 20, 0)}},
+		{"ends after", window, []Interval{span(19, 0, 22, 0)}, []Interval{span(8, 0, 19, 0)}},
+		{"outside", window, []Interval{span(6, 0, 8, 0), span(20, 0, 21, 0)}, window},
+		{"everything", window, []Interval{span(7, 0, 14, 0), span(14, 0, 21, 0)}, nil},
+		{"several ranges", []Interval{span(8, 0, 12, 0), span(14, 0, 18, 0)}, []Interval{span(11, 0, 15, 0)},
+			[]Interval{span(8, 0, 11, 0), span(15, 0, 18, 0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Subtract(tt.from, tt.remove); !equal(got, tt.want) {
+				t.Errorf("Subtract = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	a := []Interval{span(8, 0, 10, 0), span(12, 0, 14, 0)}
+	b := []Interval{span(9, 0, 13, 0), span(13, 30, 20, 0)}
+	want := []Interval{span(9, 0, 10, 0), span(12, 0, 13, 0), span(13, 30, 14, 0)}
+	if got := Intersect(a, b); !equal(got, want) {
+		t.Errorf("Intersect = %v, want %v", got, want)
+	}
+	if got := Intersect(a, nil); got != nil {
+		t.Errorf("Intersect with nothing = %v, want none", got)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	var list []Interval
+	list = Append(list, span(8, 0, 10, 0))
+	list = Append(list, span(10, 0, 11, 0)) // contiguo: se une
+	list = Append(list, span(10, 30, 10, 45))
+	list = Append(list, span(12, 0, 12, 0)) // vacío: se descarta
+	list = Append(list, span(13, 0, 14, 0))
+	want := []Interval{span(8, 0, 11, 0), span(13, 0, 14, 0)}
+	if !equal(list, want) {
+		t.Errorf("Append = %v, want %v", list, want)
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want int
+	}{{"00:00", 0}, {"08:30", 510}, {"9:05", 545}, {"24:00", 1440}} {
+		got, err := ParseClock(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseClock(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+		if tt.in != "9:05" && FormatClock(got) != tt.in {
+			t.Errorf("FormatClock(%d) = %q, want %q", got, FormatClock(got), tt.in)
+		}
+	}
+	for _, in := range []string{"", "8", "08:60", "24:30", "25:00", "-1:00", "aa:bb"} {
+		if _, err := ParseClock(in); err == nil {
+			t.Errorf("ParseClock(%q) accepted an invalid time", in)
+		}
+	}
+}
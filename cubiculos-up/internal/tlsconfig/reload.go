@@ -0,0 +1,152 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// checkInterval es cada cuánto, como mucho, se revisa si los archivos cambiaron. Kubernetes
+// actualiza los secrets montados con un retraso de hasta un minuto, así que no hace falta
+// revisar en cada handshake.
+const checkInterval = 10 * time.Second
+
+// state son el certificado y la CA cargados de los archivos.
+type state struct {
+	cert *tls.Certificate
+	pool *x509.CertPool
+}
+
+// reloader vuelve a leer los archivos cuando cambia su fecha de modificación. Si la
+// lectura falla (por ejemplo a mitad de una rotación) se conserva el estado anterior y
+// se reintenta en la siguiente revisión.
+type reloader struct {
+	cfg Config
+
+	mu      sync.Mutex
+	current *state
+	modTime [3]time.Time
+	checked time.Time
+}
+
+func newReloader(c Config) (*reloader, error) {
+	r := &reloader{cfg: c}
+	mods, err := r.modTimes()
+	if err != nil {
+		return nil, err
+	}
+	s, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.current, r.modTime, r.checked = s, mods, time.Now()
+	return r, nil
+}
+
+func (r *reloader) modTimes() ([3]time.Time, error) {
+	var mods [3]time.Time
+	for i, path := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.CAFile} {
+		// Stat sigue los symlinks "..data" con los que Kubernetes monta los secrets.
+		info, err := os.Stat(path)
+		if err != nil {
+			return mods, fmt.Errorf("tlsconfig: %w", err)
+		}
+		mods[i] = info.ModTime()
+	}
+	return mods, nil
+}
+
+func (r *reloader) load() (*state, error) {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("tlsconfig: loading %s: %w", r.cfg.CertFile, err)
+	}
+	caPEM, err := os.ReadFile(r.cfg.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("tlsconfig: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("tlsconfig: %s has no PEM certificates", r.cfg.CAFile)
+	}
+	return &state{cert: &cert, pool: pool}, nil
+}
+
+// state regresa el estado vigente, recargando los archivos si cambiaron.
+func (r *reloader) state() *state {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checked) < checkInterval {
+		return r.current
+	}
+	r.checked = time.Now()
+
+	mods, err := r.modTimes()
+	if err != nil {
+		log.Printf("WARNING: keeping current TLS certificates: %v", err)
+		return r.current
+	}
+	if mods == r.modTime {
+		return r.current
+	}
+	s, err := r.load()
+	if err != nil {
+		log.Printf("WARNING: keeping current TLS certificates: %v", err)
+		return r.current
+	}
+	r.current, r.modTime = s, mods
+	log.Printf("Reloaded TLS certificates from %s", r.cfg.CertFile)
+	return r.current
+}
+
+// reloadingCredentials delega cada handshake en unas credenciales TLS armadas con el
+// estado vigente, así que las conexiones nuevas usan los certificados rotados sin
+// reiniciar el proceso; las ya abiertas no se tocan.
+type reloadingCredentials struct {
+	files *reloader
+	build func(*state) *tls.Config
+
+	mu    sync.Mutex
+	built *state
+	creds credentials.TransportCredentials
+}
+
+func (c *reloadingCredentials) tls() credentials.TransportCredentials {
+	s := c.files.state()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.built != s {
+		c.built, c.creds = s, credentials.NewTLS(c.build(s))
+	}
+	return c.creds
+}
+
+func (c *reloadingCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return c.tls().ClientHandshake(ctx, authority, conn)
+}
+
+func (c *reloadingCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return c.tls().ServerHandshake(conn)
+}
+
+func (c *reloadingCredentials) Info() credentials.ProtocolInfo {
+	return c.tls().Info()
+}
+
+func (c *reloadingCredentials) Clone() credentials.TransportCredentials {
+	return &reloadingCredentials{files: c.files, build: c.build}
+}
+
+// OverrideServerName está obsoleto en gRPC; el nombre se configura con TLS_SERVER_NAME.
+func (c *reloadingCredentials) OverrideServerName(string) error {
+	return nil
+}
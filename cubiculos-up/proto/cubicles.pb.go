@@ -9,6 +9,8 @@ package cubiclespb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -23,11 +25,13 @@ const (
 )
 
 type Metadata struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
-	Capacity      int32                  `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Location string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Capacity int32                  `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// Un cubículo archivado (DeleteMetadata) ya no acepta reservas ni aparece en ListMetadata.
+	Archived      bool `protobuf:"varint,5,opt,name=archived,proto3" json:"archived,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -90,14 +94,40 @@ func (x *Metadata) GetCapacity() int32 {
 	return 0
 }
 
+func (x *Metadata) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+// Ciclo de vida de status:
+//
+//	PENDING -> CONFIRMED -> CHECKED_IN -> COMPLETED
+//	PENDING | CONFIRMED -> CANCELLED
+//	CONFIRMED -> NO_SHOW
+//
+// PENDING, CONFIRMED y CHECKED_IN ocupan el cubículo; los demás estados son finales.
 type Reservation struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RecordId      string                 `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
-	RecordType    string                 `protobuf:"bytes,2,opt,name=recordType,proto3" json:"recordType,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=userId,proto3" json:"userId,omitempty"`
-	Start         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start,proto3" json:"start,omitempty"`
-	End           *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end,proto3" json:"end,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Asignado por el servidor en CreateReservation.
+	RecordId string `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
+	// Obsoleto: antes guardaba el id del cubículo. Usar cubicleId.
+	//
+	// Deprecated: Marked as deprecated in cubicles.proto.
+	RecordType string                 `protobuf:"bytes,2,opt,name=recordType,proto3" json:"recordType,omitempty"`
+	UserId     string                 `protobuf:"bytes,3,opt,name=userId,proto3" json:"userId,omitempty"`
+	Start      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start,proto3" json:"start,omitempty"`
+	End        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end,proto3" json:"end,omitempty"`
+	// Asignado por el servidor en CreateReservation.
+	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// Id del cubículo (metadata.id) reservado.
+	CubicleId    string                 `protobuf:"bytes,7,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	CheckedInAt  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=checkedInAt,proto3" json:"checkedInAt,omitempty"`
+	CancelledAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=cancelledAt,proto3" json:"cancelledAt,omitempty"`
+	CancelReason string                 `protobuf:"bytes,10,opt,name=cancelReason,proto3" json:"cancelReason,omitempty"`
+	// Serie recurrente a la que pertenece; vacío si es una reserva individual.
+	SeriesId      string `protobuf:"bytes,11,opt,name=seriesId,proto3" json:"seriesId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -139,6 +169,7 @@ func (x *Reservation) GetRecordId() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in cubicles.proto.
 func (x *Reservation) GetRecordType() string {
 	if x != nil {
 		return x.RecordType
@@ -174,28 +205,79 @@ func (x *Reservation) GetStatus() string {
 	return ""
 }
 
-type Availability struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AvailableNow  bool                   `protobuf:"varint,1,opt,name=availableNow,proto3" json:"availableNow,omitempty"`
-	NextAvailable *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=nextAvailable,proto3" json:"nextAvailable,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Reservation) GetCubicleId() string {
+	if x != nil {
+		return x.CubicleId
+	}
+	return ""
 }
 
-func (x *Availability) Reset() {
-	*x = Availability{}
+func (x *Reservation) GetCheckedInAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedInAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetCancelledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetCancelReason() string {
+	if x != nil {
+		return x.CancelReason
+	}
+	return ""
+}
+
+func (x *Reservation) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+// Lugar en la lista de espera de un cubículo para [start, end). Cuando ese intervalo se
+// libera (cancelación, no-show u oferta vencida) la entrada más antigua cuyo intervalo
+// completo quedó libre recibe una oferta: una reserva PENDING a su nombre que debe
+// aceptar (AcceptWaitlistOffer) antes de offerExpiresAt.
+//
+//	WAITING -> OFFERED -> ACCEPTED
+//	WAITING | OFFERED -> LEFT (LeaveWaitlist)
+//	WAITING | OFFERED -> EXPIRED (la oferta venció o el intervalo ya empezó)
+type WaitlistEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Asignado por el servidor en JoinWaitlist.
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CubicleId      string                 `protobuf:"bytes,2,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=userId,proto3" json:"userId,omitempty"`
+	Start          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start,proto3" json:"start,omitempty"`
+	End            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end,proto3" json:"end,omitempty"`
+	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	OfferRecordId  string                 `protobuf:"bytes,8,opt,name=offerRecordId,proto3" json:"offerRecordId,omitempty"`
+	OfferExpiresAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=offerExpiresAt,proto3" json:"offerExpiresAt,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WaitlistEntry) Reset() {
+	*x = WaitlistEntry{}
 	mi := &file_cubicles_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Availability) String() string {
+func (x *WaitlistEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Availability) ProtoMessage() {}
+func (*WaitlistEntry) ProtoMessage() {}
 
-func (x *Availability) ProtoReflect() protoreflect.Message {
+func (x *WaitlistEntry) ProtoReflect() protoreflect.Message {
 	mi := &file_cubicles_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -207,47 +289,101 @@ func (x *Availability) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Availability.ProtoReflect.Descriptor instead.
-func (*Availability) Descriptor() ([]byte, []int) {
+// Deprecated: Use WaitlistEntry.ProtoReflect.Descriptor instead.
+func (*WaitlistEntry) Descriptor() ([]byte, []int) {
 	return file_cubicles_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Availability) GetAvailableNow() bool {
+func (x *WaitlistEntry) GetId() string {
 	if x != nil {
-		return x.AvailableNow
+		return x.Id
 	}
-	return false
+	return ""
 }
 
-func (x *Availability) GetNextAvailable() *timestamppb.Timestamp {
+func (x *WaitlistEntry) GetCubicleId() string {
 	if x != nil {
-		return x.NextAvailable
+		return x.CubicleId
+	}
+	return ""
+}
+
+func (x *WaitlistEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WaitlistEntry) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
 	}
 	return nil
 }
 
-type CubicleDetails struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Metadata      *Metadata              `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Reservation   *Availability          `protobuf:"bytes,2,opt,name=reservation,proto3" json:"reservation,omitempty"`
+func (x *WaitlistEntry) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *WaitlistEntry) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WaitlistEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WaitlistEntry) GetOfferRecordId() string {
+	if x != nil {
+		return x.OfferRecordId
+	}
+	return ""
+}
+
+func (x *WaitlistEntry) GetOfferExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OfferExpiresAt
+	}
+	return nil
+}
+
+type Availability struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// true si el cubículo está abierto y ninguna reserva lo ocupa en este momento.
+	AvailableNow bool `protobuf:"varint,1,opt,name=availableNow,proto3" json:"availableNow,omitempty"`
+	// Primer momento abierto y libre: ahora si availableNow. Sin valor si no hay ninguno en
+	// los próximos 14 días.
+	NextAvailable *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=nextAvailable,proto3" json:"nextAvailable,omitempty"`
+	// true si no se pudo consultar ReservationService; los demás campos no aplican.
+	Unknown       bool `protobuf:"varint,3,opt,name=unknown,proto3" json:"unknown,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CubicleDetails) Reset() {
-	*x = CubicleDetails{}
+func (x *Availability) Reset() {
+	*x = Availability{}
 	mi := &file_cubicles_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CubicleDetails) String() string {
+func (x *Availability) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CubicleDetails) ProtoMessage() {}
+func (*Availability) ProtoMessage() {}
 
-func (x *CubicleDetails) ProtoReflect() protoreflect.Message {
+func (x *Availability) ProtoReflect() protoreflect.Message {
 	mi := &file_cubicles_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -259,46 +395,55 @@ func (x *CubicleDetails) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CubicleDetails.ProtoReflect.Descriptor instead.
-func (*CubicleDetails) Descriptor() ([]byte, []int) {
+// Deprecated: Use Availability.ProtoReflect.Descriptor instead.
+func (*Availability) Descriptor() ([]byte, []int) {
 	return file_cubicles_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *CubicleDetails) GetMetadata() *Metadata {
+func (x *Availability) GetAvailableNow() bool {
 	if x != nil {
-		return x.Metadata
+		return x.AvailableNow
 	}
-	return nil
+	return false
 }
 
-func (x *CubicleDetails) GetReservation() *Availability {
+func (x *Availability) GetNextAvailable() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Reservation
+		return x.NextAvailable
 	}
 	return nil
 }
 
-type GetMetadataRequest struct {
+func (x *Availability) GetUnknown() bool {
+	if x != nil {
+		return x.Unknown
+	}
+	return false
+}
+
+// Intervalo semiabierto [start, end).
+type TimeInterval struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CubicleId     string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMetadataRequest) Reset() {
-	*x = GetMetadataRequest{}
+func (x *TimeInterval) Reset() {
+	*x = TimeInterval{}
 	mi := &file_cubicles_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMetadataRequest) String() string {
+func (x *TimeInterval) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMetadataRequest) ProtoMessage() {}
+func (*TimeInterval) ProtoMessage() {}
 
-func (x *GetMetadataRequest) ProtoReflect() protoreflect.Message {
+func (x *TimeInterval) ProtoReflect() protoreflect.Message {
 	mi := &file_cubicles_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -310,39 +455,49 @@ func (x *GetMetadataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMetadataRequest.ProtoReflect.Descriptor instead.
-func (*GetMetadataRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TimeInterval.ProtoReflect.Descriptor instead.
+func (*TimeInterval) Descriptor() ([]byte, []int) {
 	return file_cubicles_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetMetadataRequest) GetCubicleId() string {
+func (x *TimeInterval) GetStart() *timestamppb.Timestamp {
 	if x != nil {
-		return x.CubicleId
+		return x.Start
 	}
-	return ""
+	return nil
 }
 
-type GetMetadataResponse struct {
+func (x *TimeInterval) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+// Error de un elemento dentro de una respuesta batch.
+type ItemError struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Metadata      *Metadata              `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          int32                  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"` // google.golang.org/grpc/codes
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMetadataResponse) Reset() {
-	*x = GetMetadataResponse{}
+func (x *ItemError) Reset() {
+	*x = ItemError{}
 	mi := &file_cubicles_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMetadataResponse) String() string {
+func (x *ItemError) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMetadataResponse) ProtoMessage() {}
+func (*ItemError) ProtoMessage() {}
 
-func (x *GetMetadataResponse) ProtoReflect() protoreflect.Message {
+func (x *ItemError) ProtoReflect() protoreflect.Message {
 	mi := &file_cubicles_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -354,39 +509,57 @@ func (x *GetMetadataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMetadataResponse.ProtoReflect.Descriptor instead.
-func (*GetMetadataResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ItemError.ProtoReflect.Descriptor instead.
+func (*ItemError) Descriptor() ([]byte, []int) {
 	return file_cubicles_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetMetadataResponse) GetMetadata() *Metadata {
+func (x *ItemError) GetId() string {
 	if x != nil {
-		return x.Metadata
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type CreateMetadataRequest struct {
+func (x *ItemError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ItemError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Horario de un día de la semana en hora local de la ubicación. Un día puede tener varios
+// rangos (p. ej. cerrar a mediodía) siempre que no se traslapen.
+type DayHours struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Metadata      *Metadata              `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Weekday       int32                  `protobuf:"varint,1,opt,name=weekday,proto3" json:"weekday,omitempty"`    // 0 = domingo ... 6 = sábado
+	OpenTime      string                 `protobuf:"bytes,2,opt,name=openTime,proto3" json:"openTime,omitempty"`   // "HH:MM"
+	CloseTime     string                 `protobuf:"bytes,3,opt,name=closeTime,proto3" json:"closeTime,omitempty"` // "HH:MM", posterior a openTime; acepta "24:00"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateMetadataRequest) Reset() {
-	*x = CreateMetadataRequest{}
+func (x *DayHours) Reset() {
+	*x = DayHours{}
 	mi := &file_cubicles_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateMetadataRequest) String() string {
+func (x *DayHours) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateMetadataRequest) ProtoMessage() {}
+func (*DayHours) ProtoMessage() {}
 
-func (x *CreateMetadataRequest) ProtoReflect() protoreflect.Message {
+func (x *DayHours) ProtoReflect() protoreflect.Message {
 	mi := &file_cubicles_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -398,39 +571,57 @@ func (x *CreateMetadataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateMetadataRequest.ProtoReflect.Descriptor instead.
-func (*CreateMetadataRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DayHours.ProtoReflect.Descriptor instead.
+func (*DayHours) Descriptor() ([]byte, []int) {
 	return file_cubicles_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CreateMetadataRequest) GetMetadata() *Metadata {
+func (x *DayHours) GetWeekday() int32 {
 	if x != nil {
-		return x.Metadata
+		return x.Weekday
 	}
-	return nil
+	return 0
 }
 
-type CreateMetadataResponse struct {
+func (x *DayHours) GetOpenTime() string {
+	if x != nil {
+		return x.OpenTime
+	}
+	return ""
+}
+
+func (x *DayHours) GetCloseTime() string {
+	if x != nil {
+		return x.CloseTime
+	}
+	return ""
+}
+
+// Horario semanal de una ubicación. Los días sin rangos están cerrados; una ubicación sin
+// horario registrado está abierta siempre.
+type OpeningHours struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CubicleId     string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	Location      string                 `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,2,opt,name=timeZone,proto3" json:"timeZone,omitempty"` // nombre IANA, default UTC
+	Days          []*DayHours            `protobuf:"bytes,3,rep,name=days,proto3" json:"days,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateMetadataResponse) Reset() {
-	*x = CreateMetadataResponse{}
+func (x *OpeningHours) Reset() {
+	*x = OpeningHours{}
 	mi := &file_cubicles_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateMetadataResponse) String() string {
+func (x *OpeningHours) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateMetadataResponse) ProtoMessage() {}
+func (*OpeningHours) ProtoMessage() {}
 
-func (x *CreateMetadataResponse) ProtoReflect() protoreflect.Message {
+func (x *OpeningHours) ProtoReflect() protoreflect.Message {
 	mi := &file_cubicles_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -442,39 +633,62 @@ func (x *CreateMetadataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateMetadataResponse.ProtoReflect.Descriptor instead.
-func (*CreateMetadataResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use OpeningHours.ProtoReflect.Descriptor instead.
+func (*OpeningHours) Descriptor() ([]byte, []int) {
 	return file_cubicles_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *CreateMetadataResponse) GetCubicleId() string {
+func (x *OpeningHours) GetLocation() string {
 	if x != nil {
-		return x.CubicleId
+		return x.Location
 	}
 	return ""
 }
 
-type CheckAvailabilityRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CubicleId     string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+func (x *OpeningHours) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *OpeningHours) GetDays() []*DayHours {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+// Cierre de toda una ubicación (p. ej. día festivo) o de un solo cubículo (mantenimiento):
+// exactamente uno de location o cubicleId. Crear un cierre no cancela las reservas que ya
+// existen en ese intervalo.
+type Closure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Asignado por el servidor en CreateClosure.
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Location      string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	CubicleId     string                 `protobuf:"bytes,3,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end,proto3" json:"end,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckAvailabilityRequest) Reset() {
-	*x = CheckAvailabilityRequest{}
+func (x *Closure) Reset() {
+	*x = Closure{}
 	mi := &file_cubicles_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckAvailabilityRequest) String() string {
+func (x *Closure) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckAvailabilityRequest) ProtoMessage() {}
+func (*Closure) ProtoMessage() {}
 
-func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
+func (x *Closure) ProtoReflect() protoreflect.Message {
 	mi := &file_cubicles_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -486,40 +700,3228 @@ func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckAvailabilityRequest.ProtoReflect.Descriptor instead.
-func (*CheckAvailabilityRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Closure.ProtoReflect.Descriptor instead.
+func (*Closure) Descriptor() ([]byte, []int) {
 	return file_cubicles_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CheckAvailabilityRequest) GetCubicleId() string {
+func (x *Closure) GetId() string {
 	if x != nil {
-		return x.CubicleId
+		return x.Id
 	}
 	return ""
 }
 
-type CheckAvailabilityResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Availability  *Availability          `protobuf:"bytes,1,opt,name=availability,proto3" json:"availability,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Closure) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
 }
 
-func (x *CheckAvailabilityResponse) Reset() {
-	*x = CheckAvailabilityResponse{}
-	mi := &file_cubicles_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Closure) GetCubicleId() string {
+	if x != nil {
+		return x.CubicleId
+	}
+	return ""
 }
 
-func (x *CheckAvailabilityResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Closure) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *Closure) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *Closure) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Calendario efectivo de un cubículo dentro de la ventana pedida: el horario de su
+// ubicación menos los cierres que le aplican.
+type CubicleCalendar struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	CubicleId string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	Location  string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	TimeZone  string                 `protobuf:"bytes,3,opt,name=timeZone,proto3" json:"timeZone,omitempty"`
+	// Intervalos abiertos, ordenados y sin intervalos contiguos.
+	Open []*TimeInterval `protobuf:"bytes,4,rep,name=open,proto3" json:"open,omitempty"`
+	// Cierres de la ubicación y del cubículo que tocan la ventana.
+	Closures      []*Closure `protobuf:"bytes,5,rep,name=closures,proto3" json:"closures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CubicleCalendar) Reset() {
+	*x = CubicleCalendar{}
+	mi := &file_cubicles_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CubicleCalendar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CubicleCalendar) ProtoMessage() {}
+
+func (x *CubicleCalendar) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CubicleCalendar.ProtoReflect.Descriptor instead.
+func (*CubicleCalendar) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CubicleCalendar) GetCubicleId() string {
+	if x != nil {
+		return x.CubicleId
+	}
+	return ""
+}
+
+func (x *CubicleCalendar) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *CubicleCalendar) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *CubicleCalendar) GetOpen() []*TimeInterval {
+	if x != nil {
+		return x.Open
+	}
+	return nil
+}
+
+func (x *CubicleCalendar) GetClosures() []*Closure {
+	if x != nil {
+		return x.Closures
+	}
+	return nil
+}
+
+type CubicleDetails struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Metadata    *Metadata              `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Reservation *Availability          `protobuf:"bytes,2,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	// Si no está vacío, la respuesta está incompleta (p. ej. reservation.unknown) y explica por qué.
+	DegradedReason string `protobuf:"bytes,3,opt,name=degradedReason,proto3" json:"degradedReason,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CubicleDetails) Reset() {
+	*x = CubicleDetails{}
+	mi := &file_cubicles_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CubicleDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CubicleDetails) ProtoMessage() {}
+
+func (x *CubicleDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CubicleDetails.ProtoReflect.Descriptor instead.
+func (*CubicleDetails) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CubicleDetails) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CubicleDetails) GetReservation() *Availability {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+func (x *CubicleDetails) GetDegradedReason() string {
+	if x != nil {
+		return x.DegradedReason
+	}
+	return ""
+}
+
+type GetMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleId     string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMetadataRequest) Reset() {
+	*x = GetMetadataRequest{}
+	mi := &file_cubicles_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMetadataRequest) ProtoMessage() {}
+
+func (x *GetMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMetadataRequest.ProtoReflect.Descriptor instead.
+func (*GetMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetMetadataRequest) GetCubicleId() string {
+	if x != nil {
+		return x.CubicleId
+	}
+	return ""
+}
+
+type GetMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *Metadata              `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMetadataResponse) Reset() {
+	*x = GetMetadataResponse{}
+	mi := &file_cubicles_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMetadataResponse) ProtoMessage() {}
+
+func (x *GetMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMetadataResponse.ProtoReflect.Descriptor instead.
+func (*GetMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetMetadataResponse) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type CreateMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *Metadata              `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMetadataRequest) Reset() {
+	*x = CreateMetadataRequest{}
+	mi := &file_cubicles_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMetadataRequest) ProtoMessage() {}
+
+func (x *CreateMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMetadataRequest.ProtoReflect.Descriptor instead.
+func (*CreateMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateMetadataRequest) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type CreateMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleId     string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMetadataResponse) Reset() {
+	*x = CreateMetadataResponse{}
+	mi := &file_cubicles_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMetadataResponse) ProtoMessage() {}
+
+func (x *CreateMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMetadataResponse.ProtoReflect.Descriptor instead.
+func (*CreateMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateMetadataResponse) GetCubicleId() string {
+	if x != nil {
+		return x.CubicleId
+	}
+	return ""
+}
+
+// ListMetadata: todos los filtros son opcionales y se combinan con AND.
+type ListMetadataRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Location        string                 `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`                // coincidencia exacta
+	MinCapacity     int32                  `protobuf:"varint,2,opt,name=minCapacity,proto3" json:"minCapacity,omitempty"`         // capacity >= minCapacity
+	NamePrefix      string                 `protobuf:"bytes,3,opt,name=namePrefix,proto3" json:"namePrefix,omitempty"`            // name empieza con este prefijo
+	OrderBy         string                 `protobuf:"bytes,4,opt,name=orderBy,proto3" json:"orderBy,omitempty"`                  // "name" (default), "location" o "capacity"; empates por id
+	PageSize        int32                  `protobuf:"varint,5,opt,name=pageSize,proto3" json:"pageSize,omitempty"`               // default 50, máximo 200
+	PageToken       string                 `protobuf:"bytes,6,opt,name=pageToken,proto3" json:"pageToken,omitempty"`              // nextPageToken de la respuesta anterior, con los mismos filtros
+	IncludeArchived bool                   `protobuf:"varint,7,opt,name=includeArchived,proto3" json:"includeArchived,omitempty"` // incluye cubículos archivados
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListMetadataRequest) Reset() {
+	*x = ListMetadataRequest{}
+	mi := &file_cubicles_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMetadataRequest) ProtoMessage() {}
+
+func (x *ListMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMetadataRequest.ProtoReflect.Descriptor instead.
+func (*ListMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListMetadataRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *ListMetadataRequest) GetMinCapacity() int32 {
+	if x != nil {
+		return x.MinCapacity
+	}
+	return 0
+}
+
+func (x *ListMetadataRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ListMetadataRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListMetadataRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMetadataRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListMetadataRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type ListMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      []*Metadata            `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // vacío en la última página
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMetadataResponse) Reset() {
+	*x = ListMetadataResponse{}
+	mi := &file_cubicles_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMetadataResponse) ProtoMessage() {}
+
+func (x *ListMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMetadataResponse.ProtoReflect.Descriptor instead.
+func (*ListMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListMetadataResponse) GetMetadata() []*Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListMetadataResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// UpdateMetadata: updateMask lista los campos a cambiar ("name", "location", "capacity",
+// "archived"); si viene vacío se reemplazan name, location y capacity.
+type UpdateMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *Metadata              `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=updateMask,proto3" json:"updateMask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMetadataRequest) Reset() {
+	*x = UpdateMetadataRequest{}
+	mi := &file_cubicles_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMetadataRequest) ProtoMessage() {}
+
+func (x *UpdateMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMetadataRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateMetadataRequest) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UpdateMetadataRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *Metadata              `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMetadataResponse) Reset() {
+	*x = UpdateMetadataResponse{}
+	mi := &file_cubicles_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMetadataResponse) ProtoMessage() {}
+
+func (x *UpdateMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMetadataResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateMetadataResponse) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// DeleteMetadata archiva el cubículo (soft-delete); sus reservas se conservan.
+type DeleteMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleId     string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMetadataRequest) Reset() {
+	*x = DeleteMetadataRequest{}
+	mi := &file_cubicles_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMetadataRequest) ProtoMessage() {}
+
+func (x *DeleteMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMetadataRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteMetadataRequest) GetCubicleId() string {
+	if x != nil {
+		return x.CubicleId
+	}
+	return ""
+}
+
+type DeleteMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMetadataResponse) Reset() {
+	*x = DeleteMetadataResponse{}
+	mi := &file_cubicles_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMetadataResponse) ProtoMessage() {}
+
+func (x *DeleteMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMetadataResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteMetadataResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+// BatchGetMetadata: máximo 500 ids; los que no existen se reportan en errors.
+type BatchGetMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleIds    []string               `protobuf:"bytes,1,rep,name=cubicleIds,proto3" json:"cubicleIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetMetadataRequest) Reset() {
+	*x = BatchGetMetadataRequest{}
+	mi := &file_cubicles_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetMetadataRequest) ProtoMessage() {}
+
+func (x *BatchGetMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetMetadataRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BatchGetMetadataRequest) GetCubicleIds() []string {
+	if x != nil {
+		return x.CubicleIds
+	}
+	return nil
+}
+
+type BatchGetMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      []*Metadata            `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty"`
+	Errors        []*ItemError           `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetMetadataResponse) Reset() {
+	*x = BatchGetMetadataResponse{}
+	mi := &file_cubicles_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetMetadataResponse) ProtoMessage() {}
+
+func (x *BatchGetMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetMetadataResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BatchGetMetadataResponse) GetMetadata() []*Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *BatchGetMetadataResponse) GetErrors() []*ItemError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// SetOpeningHours reemplaza el horario completo de la ubicación.
+type SetOpeningHoursRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hours         *OpeningHours          `protobuf:"bytes,1,opt,name=hours,proto3" json:"hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOpeningHoursRequest) Reset() {
+	*x = SetOpeningHoursRequest{}
+	mi := &file_cubicles_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOpeningHoursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOpeningHoursRequest) ProtoMessage() {}
+
+func (x *SetOpeningHoursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOpeningHoursRequest.ProtoReflect.Descriptor instead.
+func (*SetOpeningHoursRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetOpeningHoursRequest) GetHours() *OpeningHours {
+	if x != nil {
+		return x.Hours
+	}
+	return nil
+}
+
+type SetOpeningHoursResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hours         *OpeningHours          `protobuf:"bytes,1,opt,name=hours,proto3" json:"hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOpeningHoursResponse) Reset() {
+	*x = SetOpeningHoursResponse{}
+	mi := &file_cubicles_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOpeningHoursResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOpeningHoursResponse) ProtoMessage() {}
+
+func (x *SetOpeningHoursResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOpeningHoursResponse.ProtoReflect.Descriptor instead.
+func (*SetOpeningHoursResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetOpeningHoursResponse) GetHours() *OpeningHours {
+	if x != nil {
+		return x.Hours
+	}
+	return nil
+}
+
+type GetOpeningHoursRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      string                 `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOpeningHoursRequest) Reset() {
+	*x = GetOpeningHoursRequest{}
+	mi := &file_cubicles_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOpeningHoursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOpeningHoursRequest) ProtoMessage() {}
+
+func (x *GetOpeningHoursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOpeningHoursRequest.ProtoReflect.Descriptor instead.
+func (*GetOpeningHoursRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetOpeningHoursRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+type GetOpeningHoursResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hours         *OpeningHours          `protobuf:"bytes,1,opt,name=hours,proto3" json:"hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOpeningHoursResponse) Reset() {
+	*x = GetOpeningHoursResponse{}
+	mi := &file_cubicles_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOpeningHoursResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOpeningHoursResponse) ProtoMessage() {}
+
+func (x *GetOpeningHoursResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOpeningHoursResponse.ProtoReflect.Descriptor instead.
+func (*GetOpeningHoursResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetOpeningHoursResponse) GetHours() *OpeningHours {
+	if x != nil {
+		return x.Hours
+	}
+	return nil
+}
+
+// DeleteOpeningHours deja la ubicación abierta siempre.
+type DeleteOpeningHoursRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      string                 `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOpeningHoursRequest) Reset() {
+	*x = DeleteOpeningHoursRequest{}
+	mi := &file_cubicles_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOpeningHoursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOpeningHoursRequest) ProtoMessage() {}
+
+func (x *DeleteOpeningHoursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOpeningHoursRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOpeningHoursRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteOpeningHoursRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+type DeleteOpeningHoursResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOpeningHoursResponse) Reset() {
+	*x = DeleteOpeningHoursResponse{}
+	mi := &file_cubicles_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOpeningHoursResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOpeningHoursResponse) ProtoMessage() {}
+
+func (x *DeleteOpeningHoursResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOpeningHoursResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOpeningHoursResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteOpeningHoursResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+type CreateClosureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Closure       *Closure               `protobuf:"bytes,1,opt,name=closure,proto3" json:"closure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateClosureRequest) Reset() {
+	*x = CreateClosureRequest{}
+	mi := &file_cubicles_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateClosureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateClosureRequest) ProtoMessage() {}
+
+func (x *CreateClosureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateClosureRequest.ProtoReflect.Descriptor instead.
+func (*CreateClosureRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateClosureRequest) GetClosure() *Closure {
+	if x != nil {
+		return x.Closure
+	}
+	return nil
+}
+
+type CreateClosureResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Closure       *Closure               `protobuf:"bytes,1,opt,name=closure,proto3" json:"closure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateClosureResponse) Reset() {
+	*x = CreateClosureResponse{}
+	mi := &file_cubicles_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateClosureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateClosureResponse) ProtoMessage() {}
+
+func (x *CreateClosureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateClosureResponse.ProtoReflect.Descriptor instead.
+func (*CreateClosureResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateClosureResponse) GetClosure() *Closure {
+	if x != nil {
+		return x.Closure
+	}
+	return nil
+}
+
+type DeleteClosureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClosureId     string                 `protobuf:"bytes,1,opt,name=closureId,proto3" json:"closureId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteClosureRequest) Reset() {
+	*x = DeleteClosureRequest{}
+	mi := &file_cubicles_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteClosureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteClosureRequest) ProtoMessage() {}
+
+func (x *DeleteClosureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteClosureRequest.ProtoReflect.Descriptor instead.
+func (*DeleteClosureRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteClosureRequest) GetClosureId() string {
+	if x != nil {
+		return x.ClosureId
+	}
+	return ""
+}
+
+type DeleteClosureResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteClosureResponse) Reset() {
+	*x = DeleteClosureResponse{}
+	mi := &file_cubicles_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteClosureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteClosureResponse) ProtoMessage() {}
+
+func (x *DeleteClosureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteClosureResponse.ProtoReflect.Descriptor instead.
+func (*DeleteClosureResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteClosureResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+// ListClosures: filtros opcionales combinados con AND; con from/to se regresan los
+// cierres que se traslapan con [from, to). Ordenados por start; sin paginar.
+type ListClosuresRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      string                 `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	CubicleId     string                 `protobuf:"bytes,2,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClosuresRequest) Reset() {
+	*x = ListClosuresRequest{}
+	mi := &file_cubicles_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClosuresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClosuresRequest) ProtoMessage() {}
+
+func (x *ListClosuresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClosuresRequest.ProtoReflect.Descriptor instead.
+func (*ListClosuresRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListClosuresRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *ListClosuresRequest) GetCubicleId() string {
+	if x != nil {
+		return x.CubicleId
+	}
+	return ""
+}
+
+func (x *ListClosuresRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListClosuresRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type ListClosuresResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Closures      []*Closure             `protobuf:"bytes,1,rep,name=closures,proto3" json:"closures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClosuresResponse) Reset() {
+	*x = ListClosuresResponse{}
+	mi := &file_cubicles_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClosuresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClosuresResponse) ProtoMessage() {}
+
+func (x *ListClosuresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClosuresResponse.ProtoReflect.Descriptor instead.
+func (*ListClosuresResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListClosuresResponse) GetClosures() []*Closure {
+	if x != nil {
+		return x.Closures
+	}
+	return nil
+}
+
+// GetCalendars: máximo 500 ids y una ventana [from, to) de máximo 31 días; los ids que no
+// existen se reportan en errors.
+type GetCalendarsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleIds    []string               `protobuf:"bytes,1,rep,name=cubicleIds,proto3" json:"cubicleIds,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCalendarsRequest) Reset() {
+	*x = GetCalendarsRequest{}
+	mi := &file_cubicles_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCalendarsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCalendarsRequest) ProtoMessage() {}
+
+func (x *GetCalendarsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCalendarsRequest.ProtoReflect.Descriptor instead.
+func (*GetCalendarsRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetCalendarsRequest) GetCubicleIds() []string {
+	if x != nil {
+		return x.CubicleIds
+	}
+	return nil
+}
+
+func (x *GetCalendarsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetCalendarsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type GetCalendarsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Calendars     []*CubicleCalendar     `protobuf:"bytes,1,rep,name=calendars,proto3" json:"calendars,omitempty"`
+	Errors        []*ItemError           `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCalendarsResponse) Reset() {
+	*x = GetCalendarsResponse{}
+	mi := &file_cubicles_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCalendarsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCalendarsResponse) ProtoMessage() {}
+
+func (x *GetCalendarsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCalendarsResponse.ProtoReflect.Descriptor instead.
+func (*GetCalendarsResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetCalendarsResponse) GetCalendars() []*CubicleCalendar {
+	if x != nil {
+		return x.Calendars
+	}
+	return nil
+}
+
+func (x *GetCalendarsResponse) GetErrors() []*ItemError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type CheckAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleId     string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityRequest) Reset() {
+	*x = CheckAvailabilityRequest{}
+	mi := &file_cubicles_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityRequest) ProtoMessage() {}
+
+func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CheckAvailabilityRequest) GetCubicleId() string {
+	if x != nil {
+		return x.CubicleId
+	}
+	return ""
+}
+
+type CheckAvailabilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Availability  *Availability          `protobuf:"bytes,1,opt,name=availability,proto3" json:"availability,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAvailabilityResponse) Reset() {
+	*x = CheckAvailabilityResponse{}
+	mi := &file_cubicles_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAvailabilityResponse) ProtoMessage() {}
+
+func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CheckAvailabilityResponse) GetAvailability() *Availability {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+// BatchCheckAvailability: máximo 500 ids, resueltos con una sola consulta. Los ids que
+// MetadataService no conoce salen en errors con NotFound, igual que en BatchGetMetadata.
+type BatchCheckAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleIds    []string               `protobuf:"bytes,1,rep,name=cubicleIds,proto3" json:"cubicleIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCheckAvailabilityRequest) Reset() {
+	*x = BatchCheckAvailabilityRequest{}
+	mi := &file_cubicles_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCheckAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCheckAvailabilityRequest) ProtoMessage() {}
+
+func (x *BatchCheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCheckAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*BatchCheckAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *BatchCheckAvailabilityRequest) GetCubicleIds() []string {
+	if x != nil {
+		return x.CubicleIds
+	}
+	return nil
+}
+
+type CubicleAvailability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleId     string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	Availability  *Availability          `protobuf:"bytes,2,opt,name=availability,proto3" json:"availability,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CubicleAvailability) Reset() {
+	*x = CubicleAvailability{}
+	mi := &file_cubicles_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CubicleAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CubicleAvailability) ProtoMessage() {}
+
+func (x *CubicleAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CubicleAvailability.ProtoReflect.Descriptor instead.
+func (*CubicleAvailability) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CubicleAvailability) GetCubicleId() string {
+	if x != nil {
+		return x.CubicleId
+	}
+	return ""
+}
+
+func (x *CubicleAvailability) GetAvailability() *Availability {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+type BatchCheckAvailabilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Availability  []*CubicleAvailability `protobuf:"bytes,1,rep,name=availability,proto3" json:"availability,omitempty"`
+	Errors        []*ItemError           `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCheckAvailabilityResponse) Reset() {
+	*x = BatchCheckAvailabilityResponse{}
+	mi := &file_cubicles_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCheckAvailabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCheckAvailabilityResponse) ProtoMessage() {}
+
+func (x *BatchCheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCheckAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*BatchCheckAvailabilityResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *BatchCheckAvailabilityResponse) GetAvailability() []*CubicleAvailability {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+func (x *BatchCheckAvailabilityResponse) GetErrors() []*ItemError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// FindFreeCubicles: de cubicleIds (máximo 500), los que ninguna reserva activa ocupa en
+// [from, to), en el orden pedido. No revisa el calendario y no expone reservas ni
+// usuarios, así que el resultado no depende de quién llama.
+type FindFreeCubiclesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleIds    []string               `protobuf:"bytes,1,rep,name=cubicleIds,proto3" json:"cubicleIds,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindFreeCubiclesRequest) Reset() {
+	*x = FindFreeCubiclesRequest{}
+	mi := &file_cubicles_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindFreeCubiclesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindFreeCubiclesRequest) ProtoMessage() {}
+
+func (x *FindFreeCubiclesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindFreeCubiclesRequest.ProtoReflect.Descriptor instead.
+func (*FindFreeCubiclesRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *FindFreeCubiclesRequest) GetCubicleIds() []string {
+	if x != nil {
+		return x.CubicleIds
+	}
+	return nil
+}
+
+func (x *FindFreeCubiclesRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *FindFreeCubiclesRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type FindFreeCubiclesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleIds    []string               `protobuf:"bytes,1,rep,name=cubicleIds,proto3" json:"cubicleIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindFreeCubiclesResponse) Reset() {
+	*x = FindFreeCubiclesResponse{}
+	mi := &file_cubicles_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindFreeCubiclesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindFreeCubiclesResponse) ProtoMessage() {}
+
+func (x *FindFreeCubiclesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindFreeCubiclesResponse.ProtoReflect.Descriptor instead.
+func (*FindFreeCubiclesResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *FindFreeCubiclesResponse) GetCubicleIds() []string {
+	if x != nil {
+		return x.CubicleIds
+	}
+	return nil
+}
+
+type GetCubicleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleId     string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCubicleRequest) Reset() {
+	*x = GetCubicleRequest{}
+	mi := &file_cubicles_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCubicleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCubicleRequest) ProtoMessage() {}
+
+func (x *GetCubicleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCubicleRequest.ProtoReflect.Descriptor instead.
+func (*GetCubicleRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetCubicleRequest) GetCubicleId() string {
+	if x != nil {
+		return x.CubicleId
+	}
+	return ""
+}
+
+type GetCubicleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Details       *CubicleDetails        `protobuf:"bytes,1,opt,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCubicleResponse) Reset() {
+	*x = GetCubicleResponse{}
+	mi := &file_cubicles_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCubicleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCubicleResponse) ProtoMessage() {}
+
+func (x *GetCubicleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCubicleResponse.ProtoReflect.Descriptor instead.
+func (*GetCubicleResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetCubicleResponse) GetDetails() *CubicleDetails {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// SearchCubicles: cubículos no archivados que cumplen location/minCapacity y están abiertos
+// y libres durante todo [start, end). La ventana dura como máximo 31 días.
+type SearchCubiclesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      string                 `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`        // opcional, coincidencia exacta
+	MinCapacity   int32                  `protobuf:"varint,2,opt,name=minCapacity,proto3" json:"minCapacity,omitempty"` // número de personas
+	Start         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	MaxResults    int32                  `protobuf:"varint,5,opt,name=maxResults,proto3" json:"maxResults,omitempty"` // default 10, máximo 50
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCubiclesRequest) Reset() {
+	*x = SearchCubiclesRequest{}
+	mi := &file_cubicles_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCubiclesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCubiclesRequest) ProtoMessage() {}
+
+func (x *SearchCubiclesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCubiclesRequest.ProtoReflect.Descriptor instead.
+func (*SearchCubiclesRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SearchCubiclesRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *SearchCubiclesRequest) GetMinCapacity() int32 {
+	if x != nil {
+		return x.MinCapacity
+	}
+	return 0
+}
+
+func (x *SearchCubiclesRequest) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *SearchCubiclesRequest) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *SearchCubiclesRequest) GetMaxResults() int32 {
+	if x != nil {
+		return x.MaxResults
+	}
+	return 0
+}
+
+// BatchGetCubicles: máximo 500 ids; un resultado por id en el orden pedido, con details
+// o con error.
+type BatchGetCubiclesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleIds    []string               `protobuf:"bytes,1,rep,name=cubicleIds,proto3" json:"cubicleIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetCubiclesRequest) Reset() {
+	*x = BatchGetCubiclesRequest{}
+	mi := &file_cubicles_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetCubiclesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetCubiclesRequest) ProtoMessage() {}
+
+func (x *BatchGetCubiclesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetCubiclesRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetCubiclesRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *BatchGetCubiclesRequest) GetCubicleIds() []string {
+	if x != nil {
+		return x.CubicleIds
+	}
+	return nil
+}
+
+type CubicleResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleId     string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	Details       *CubicleDetails        `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`
+	Error         *ItemError             `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CubicleResult) Reset() {
+	*x = CubicleResult{}
+	mi := &file_cubicles_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CubicleResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CubicleResult) ProtoMessage() {}
+
+func (x *CubicleResult) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CubicleResult.ProtoReflect.Descriptor instead.
+func (*CubicleResult) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CubicleResult) GetCubicleId() string {
+	if x != nil {
+		return x.CubicleId
+	}
+	return ""
+}
+
+func (x *CubicleResult) GetDetails() *CubicleDetails {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *CubicleResult) GetError() *ItemError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type BatchGetCubiclesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*CubicleResult       `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetCubiclesResponse) Reset() {
+	*x = BatchGetCubiclesResponse{}
+	mi := &file_cubicles_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetCubiclesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetCubiclesResponse) ProtoMessage() {}
+
+func (x *BatchGetCubiclesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetCubiclesResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetCubiclesResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *BatchGetCubiclesResponse) GetResults() []*CubicleResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type CubicleCandidate struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Metadata *Metadata              `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// capacity - minCapacity; los candidatos se ordenan de menor a mayor para no ocupar
+	// un cubículo grande con un grupo pequeño.
+	SpareSeats    int32 `protobuf:"varint,2,opt,name=spareSeats,proto3" json:"spareSeats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CubicleCandidate) Reset() {
+	*x = CubicleCandidate{}
+	mi := &file_cubicles_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CubicleCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CubicleCandidate) ProtoMessage() {}
+
+func (x *CubicleCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CubicleCandidate.ProtoReflect.Descriptor instead.
+func (*CubicleCandidate) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CubicleCandidate) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CubicleCandidate) GetSpareSeats() int32 {
+	if x != nil {
+		return x.SpareSeats
+	}
+	return 0
+}
+
+type SearchCubiclesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Candidates    []*CubicleCandidate    `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCubiclesResponse) Reset() {
+	*x = SearchCubiclesResponse{}
+	mi := &file_cubicles_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCubiclesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCubiclesResponse) ProtoMessage() {}
+
+func (x *SearchCubiclesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCubiclesResponse.ProtoReflect.Descriptor instead.
+func (*SearchCubiclesResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SearchCubiclesResponse) GetCandidates() []*CubicleCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+// Opcionales para manejo de reservaciones
+// CreateReservation con recurrence crea una serie: reservation.start/end es la primera
+// ocurrencia y la regla se expande en timeZone. Se reservan todas las ocurrencias o
+// ninguna; si alguna falla el status trae un OccurrenceError por cada ocurrencia rechazada.
+type CreateReservationRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Reservation *Reservation           `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	// Subconjunto de RRULE (RFC 5545): FREQ=DAILY|WEEKLY, INTERVAL, BYDAY, y COUNT o UNTIL.
+	// P. ej. "FREQ=WEEKLY;BYDAY=TU;UNTIL=20270601". Máximo 100 ocurrencias.
+	Recurrence    string `protobuf:"bytes,2,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	TimeZone      string `protobuf:"bytes,3,opt,name=timeZone,proto3" json:"timeZone,omitempty"` // nombre IANA; default la zona del horario de la ubicación
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReservationRequest) Reset() {
+	*x = CreateReservationRequest{}
+	mi := &file_cubicles_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReservationRequest) ProtoMessage() {}
+
+func (x *CreateReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReservationRequest.ProtoReflect.Descriptor instead.
+func (*CreateReservationRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *CreateReservationRequest) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+func (x *CreateReservationRequest) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *CreateReservationRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type CreateReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordId      string                 `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`   // primera ocurrencia
+	SeriesId      string                 `protobuf:"bytes,2,opt,name=seriesId,proto3" json:"seriesId,omitempty"`   // solo con recurrence
+	RecordIds     []string               `protobuf:"bytes,3,rep,name=recordIds,proto3" json:"recordIds,omitempty"` // todas las ocurrencias, en orden
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReservationResponse) Reset() {
+	*x = CreateReservationResponse{}
+	mi := &file_cubicles_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReservationResponse) ProtoMessage() {}
+
+func (x *CreateReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReservationResponse.ProtoReflect.Descriptor instead.
+func (*CreateReservationResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CreateReservationResponse) GetRecordId() string {
+	if x != nil {
+		return x.RecordId
+	}
+	return ""
+}
+
+func (x *CreateReservationResponse) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *CreateReservationResponse) GetRecordIds() []string {
+	if x != nil {
+		return x.RecordIds
+	}
+	return nil
+}
+
+// Ocurrencia de una serie que no se pudo reservar; viaja como detalle del status de
+// CreateReservation.
+type OccurrenceError struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Index               int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // posición en la serie, desde 0
+	Occurrence          *TimeInterval          `protobuf:"bytes,2,opt,name=occurrence,proto3" json:"occurrence,omitempty"`
+	Code                int32                  `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"` // google.golang.org/grpc/codes
+	Message             string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	ConflictingRecordId string                 `protobuf:"bytes,5,opt,name=conflictingRecordId,proto3" json:"conflictingRecordId,omitempty"` // reserva que ya ocupa el cubículo, si aplica
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *OccurrenceError) Reset() {
+	*x = OccurrenceError{}
+	mi := &file_cubicles_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OccurrenceError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OccurrenceError) ProtoMessage() {}
+
+func (x *OccurrenceError) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OccurrenceError.ProtoReflect.Descriptor instead.
+func (*OccurrenceError) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *OccurrenceError) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *OccurrenceError) GetOccurrence() *TimeInterval {
+	if x != nil {
+		return x.Occurrence
+	}
+	return nil
+}
+
+func (x *OccurrenceError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *OccurrenceError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *OccurrenceError) GetConflictingRecordId() string {
+	if x != nil {
+		return x.ConflictingRecordId
+	}
+	return ""
+}
+
+// Las transiciones regresan la reserva ya actualizada; una transición no permitida desde
+// el estado actual es FailedPrecondition.
+type CancelReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordId      string                 `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelReservationRequest) Reset() {
+	*x = CancelReservationRequest{}
+	mi := &file_cubicles_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelReservationRequest) ProtoMessage() {}
+
+func (x *CancelReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelReservationRequest.ProtoReflect.Descriptor instead.
+func (*CancelReservationRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *CancelReservationRequest) GetRecordId() string {
+	if x != nil {
+		return x.RecordId
+	}
+	return ""
+}
+
+func (x *CancelReservationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Reservation   *Reservation           `protobuf:"bytes,2,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelReservationResponse) Reset() {
+	*x = CancelReservationResponse{}
+	mi := &file_cubicles_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelReservationResponse) ProtoMessage() {}
+
+func (x *CancelReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelReservationResponse.ProtoReflect.Descriptor instead.
+func (*CancelReservationResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *CancelReservationResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *CancelReservationResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+// CancelSeries cancela las ocurrencias PENDING o CONFIRMED de la serie que empiezan en o
+// después de from (default: todas). Para cancelar una sola ocurrencia usar
+// CancelReservation con su recordId.
+type CancelSeriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeriesId      string                 `protobuf:"bytes,1,opt,name=seriesId,proto3" json:"seriesId,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelSeriesRequest) Reset() {
+	*x = CancelSeriesRequest{}
+	mi := &file_cubicles_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSeriesRequest) ProtoMessage() {}
+
+func (x *CancelSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSeriesRequest.ProtoReflect.Descriptor instead.
+func (*CancelSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *CancelSeriesRequest) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *CancelSeriesRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CancelSeriesRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+type CancelSeriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cancelled     []*Reservation         `protobuf:"bytes,1,rep,name=cancelled,proto3" json:"cancelled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelSeriesResponse) Reset() {
+	*x = CancelSeriesResponse{}
+	mi := &file_cubicles_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSeriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSeriesResponse) ProtoMessage() {}
+
+func (x *CancelSeriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSeriesResponse.ProtoReflect.Descriptor instead.
+func (*CancelSeriesResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *CancelSeriesResponse) GetCancelled() []*Reservation {
+	if x != nil {
+		return x.Cancelled
+	}
+	return nil
+}
+
+// JoinWaitlist solo acepta intervalos que hoy chocan con otra reserva; si está libre se
+// responde FailedPrecondition y hay que usar CreateReservation.
+type JoinWaitlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleId     string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End           *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinWaitlistRequest) Reset() {
+	*x = JoinWaitlistRequest{}
+	mi := &file_cubicles_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinWaitlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinWaitlistRequest) ProtoMessage() {}
+
+func (x *JoinWaitlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinWaitlistRequest.ProtoReflect.Descriptor instead.
+func (*JoinWaitlistRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *JoinWaitlistRequest) GetCubicleId() string {
+	if x != nil {
+		return x.CubicleId
+	}
+	return ""
+}
+
+func (x *JoinWaitlistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *JoinWaitlistRequest) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *JoinWaitlistRequest) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+type JoinWaitlistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *WaitlistEntry         `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinWaitlistResponse) Reset() {
+	*x = JoinWaitlistResponse{}
+	mi := &file_cubicles_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinWaitlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinWaitlistResponse) ProtoMessage() {}
+
+func (x *JoinWaitlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinWaitlistResponse.ProtoReflect.Descriptor instead.
+func (*JoinWaitlistResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *JoinWaitlistResponse) GetEntry() *WaitlistEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+// LeaveWaitlist sale de la fila; si la entrada tenía una oferta, su reserva se cancela y
+// el intervalo se ofrece al siguiente.
+type LeaveWaitlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       string                 `protobuf:"bytes,1,opt,name=entryId,proto3" json:"entryId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveWaitlistRequest) Reset() {
+	*x = LeaveWaitlistRequest{}
+	mi := &file_cubicles_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveWaitlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveWaitlistRequest) ProtoMessage() {}
+
+func (x *LeaveWaitlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveWaitlistRequest.ProtoReflect.Descriptor instead.
+func (*LeaveWaitlistRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *LeaveWaitlistRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+type LeaveWaitlistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *WaitlistEntry         `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveWaitlistResponse) Reset() {
+	*x = LeaveWaitlistResponse{}
+	mi := &file_cubicles_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveWaitlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveWaitlistResponse) ProtoMessage() {}
+
+func (x *LeaveWaitlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveWaitlistResponse.ProtoReflect.Descriptor instead.
+func (*LeaveWaitlistResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *LeaveWaitlistResponse) GetEntry() *WaitlistEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type AcceptWaitlistOfferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntryId       string                 `protobuf:"bytes,1,opt,name=entryId,proto3" json:"entryId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptWaitlistOfferRequest) Reset() {
+	*x = AcceptWaitlistOfferRequest{}
+	mi := &file_cubicles_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptWaitlistOfferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptWaitlistOfferRequest) ProtoMessage() {}
+
+func (x *AcceptWaitlistOfferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptWaitlistOfferRequest.ProtoReflect.Descriptor instead.
+func (*AcceptWaitlistOfferRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *AcceptWaitlistOfferRequest) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+type AcceptWaitlistOfferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *WaitlistEntry         `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	Reservation   *Reservation           `protobuf:"bytes,2,opt,name=reservation,proto3" json:"reservation,omitempty"` // ya CONFIRMED
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptWaitlistOfferResponse) Reset() {
+	*x = AcceptWaitlistOfferResponse{}
+	mi := &file_cubicles_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptWaitlistOfferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptWaitlistOfferResponse) ProtoMessage() {}
+
+func (x *AcceptWaitlistOfferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptWaitlistOfferResponse.ProtoReflect.Descriptor instead.
+func (*AcceptWaitlistOfferResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *AcceptWaitlistOfferResponse) GetEntry() *WaitlistEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *AcceptWaitlistOfferResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+// ListWaitlist: filtros opcionales combinados con AND, ordenado por llegada; máximo 200.
+type ListWaitlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CubicleId     string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Status        []string               `protobuf:"bytes,3,rep,name=status,proto3" json:"status,omitempty"`       // vacío = cualquiera
+	PageSize      int32                  `protobuf:"varint,4,opt,name=pageSize,proto3" json:"pageSize,omitempty"`  // default 50, máximo 200
+	PageToken     string                 `protobuf:"bytes,5,opt,name=pageToken,proto3" json:"pageToken,omitempty"` // nextPageToken de la respuesta anterior, con los mismos filtros
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWaitlistRequest) Reset() {
+	*x = ListWaitlistRequest{}
+	mi := &file_cubicles_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWaitlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWaitlistRequest) ProtoMessage() {}
+
+func (x *ListWaitlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWaitlistRequest.ProtoReflect.Descriptor instead.
+func (*ListWaitlistRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ListWaitlistRequest) GetCubicleId() string {
+	if x != nil {
+		return x.CubicleId
+	}
+	return ""
+}
+
+func (x *ListWaitlistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListWaitlistRequest) GetStatus() []string {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ListWaitlistRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListWaitlistRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListWaitlistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*WaitlistEntry       `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // vacío en la última página
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWaitlistResponse) Reset() {
+	*x = ListWaitlistResponse{}
+	mi := &file_cubicles_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWaitlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWaitlistResponse) ProtoMessage() {}
+
+func (x *ListWaitlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWaitlistResponse.ProtoReflect.Descriptor instead.
+func (*ListWaitlistResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListWaitlistResponse) GetEntries() []*WaitlistEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListWaitlistResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ConfirmReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordId      string                 `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmReservationRequest) Reset() {
+	*x = ConfirmReservationRequest{}
+	mi := &file_cubicles_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmReservationRequest) ProtoMessage() {}
+
+func (x *ConfirmReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmReservationRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmReservationRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ConfirmReservationRequest) GetRecordId() string {
+	if x != nil {
+		return x.RecordId
+	}
+	return ""
+}
+
+type ConfirmReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *Reservation           `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmReservationResponse) Reset() {
+	*x = ConfirmReservationResponse{}
+	mi := &file_cubicles_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmReservationResponse) ProtoMessage() {}
+
+func (x *ConfirmReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmReservationResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmReservationResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ConfirmReservationResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+type CheckInRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordId      string                 `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckInRequest) Reset() {
+	*x = CheckInRequest{}
+	mi := &file_cubicles_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckInRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckInRequest) ProtoMessage() {}
+
+func (x *CheckInRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckInRequest.ProtoReflect.Descriptor instead.
+func (*CheckInRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CheckInRequest) GetRecordId() string {
+	if x != nil {
+		return x.RecordId
+	}
+	return ""
+}
+
+type CheckInResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *Reservation           `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckInResponse) Reset() {
+	*x = CheckInResponse{}
+	mi := &file_cubicles_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckInResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckAvailabilityResponse) ProtoMessage() {}
+func (*CheckInResponse) ProtoMessage() {}
 
-func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cubicles_proto_msgTypes[9]
+func (x *CheckInResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -530,40 +3932,40 @@ func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckAvailabilityResponse.ProtoReflect.Descriptor instead.
-func (*CheckAvailabilityResponse) Descriptor() ([]byte, []int) {
-	return file_cubicles_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use CheckInResponse.ProtoReflect.Descriptor instead.
+func (*CheckInResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *CheckAvailabilityResponse) GetAvailability() *Availability {
+func (x *CheckInResponse) GetReservation() *Reservation {
 	if x != nil {
-		return x.Availability
+		return x.Reservation
 	}
 	return nil
 }
 
-type GetCubicleRequest struct {
+type CompleteReservationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CubicleId     string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	RecordId      string                 `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCubicleRequest) Reset() {
-	*x = GetCubicleRequest{}
-	mi := &file_cubicles_proto_msgTypes[10]
+func (x *CompleteReservationRequest) Reset() {
+	*x = CompleteReservationRequest{}
+	mi := &file_cubicles_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCubicleRequest) String() string {
+func (x *CompleteReservationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCubicleRequest) ProtoMessage() {}
+func (*CompleteReservationRequest) ProtoMessage() {}
 
-func (x *GetCubicleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cubicles_proto_msgTypes[10]
+func (x *CompleteReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -574,40 +3976,40 @@ func (x *GetCubicleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCubicleRequest.ProtoReflect.Descriptor instead.
-func (*GetCubicleRequest) Descriptor() ([]byte, []int) {
-	return file_cubicles_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use CompleteReservationRequest.ProtoReflect.Descriptor instead.
+func (*CompleteReservationRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *GetCubicleRequest) GetCubicleId() string {
+func (x *CompleteReservationRequest) GetRecordId() string {
 	if x != nil {
-		return x.CubicleId
+		return x.RecordId
 	}
 	return ""
 }
 
-type GetCubicleResponse struct {
+type CompleteReservationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Details       *CubicleDetails        `protobuf:"bytes,1,opt,name=details,proto3" json:"details,omitempty"`
+	Reservation   *Reservation           `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCubicleResponse) Reset() {
-	*x = GetCubicleResponse{}
-	mi := &file_cubicles_proto_msgTypes[11]
+func (x *CompleteReservationResponse) Reset() {
+	*x = CompleteReservationResponse{}
+	mi := &file_cubicles_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCubicleResponse) String() string {
+func (x *CompleteReservationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCubicleResponse) ProtoMessage() {}
+func (*CompleteReservationResponse) ProtoMessage() {}
 
-func (x *GetCubicleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cubicles_proto_msgTypes[11]
+func (x *CompleteReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -618,41 +4020,84 @@ func (x *GetCubicleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCubicleResponse.ProtoReflect.Descriptor instead.
-func (*GetCubicleResponse) Descriptor() ([]byte, []int) {
-	return file_cubicles_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use CompleteReservationResponse.ProtoReflect.Descriptor instead.
+func (*CompleteReservationResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *GetCubicleResponse) GetDetails() *CubicleDetails {
+func (x *CompleteReservationResponse) GetReservation() *Reservation {
 	if x != nil {
-		return x.Details
+		return x.Reservation
 	}
 	return nil
 }
 
-// Opcionales para manejo de reservaciones
-type CreateReservationRequest struct {
+type MarkNoShowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecordId      string                 `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkNoShowRequest) Reset() {
+	*x = MarkNoShowRequest{}
+	mi := &file_cubicles_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNoShowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNoShowRequest) ProtoMessage() {}
+
+func (x *MarkNoShowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNoShowRequest.ProtoReflect.Descriptor instead.
+func (*MarkNoShowRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *MarkNoShowRequest) GetRecordId() string {
+	if x != nil {
+		return x.RecordId
+	}
+	return ""
+}
+
+type MarkNoShowResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Reservation   *Reservation           `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateReservationRequest) Reset() {
-	*x = CreateReservationRequest{}
-	mi := &file_cubicles_proto_msgTypes[12]
+func (x *MarkNoShowResponse) Reset() {
+	*x = MarkNoShowResponse{}
+	mi := &file_cubicles_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateReservationRequest) String() string {
+func (x *MarkNoShowResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateReservationRequest) ProtoMessage() {}
+func (*MarkNoShowResponse) ProtoMessage() {}
 
-func (x *CreateReservationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cubicles_proto_msgTypes[12]
+func (x *MarkNoShowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -663,40 +4108,50 @@ func (x *CreateReservationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateReservationRequest.ProtoReflect.Descriptor instead.
-func (*CreateReservationRequest) Descriptor() ([]byte, []int) {
-	return file_cubicles_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use MarkNoShowResponse.ProtoReflect.Descriptor instead.
+func (*MarkNoShowResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *CreateReservationRequest) GetReservation() *Reservation {
+func (x *MarkNoShowResponse) GetReservation() *Reservation {
 	if x != nil {
 		return x.Reservation
 	}
 	return nil
 }
 
-type CreateReservationResponse struct {
+// ListReservations: todos los filtros son opcionales y se combinan con AND. Con from/to
+// se regresan las reservas cuyo intervalo [start, end) se traslapa con [from, to).
+// El resultado se ordena por start y recordId.
+type ListReservationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RecordId      string                 `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
+	CubicleId     string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Status        []string               `protobuf:"bytes,3,rep,name=status,proto3" json:"status,omitempty"` // p. ej. ["CONFIRMED"]; vacío = cualquiera
+	From          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	PageSize      int32                  `protobuf:"varint,6,opt,name=pageSize,proto3" json:"pageSize,omitempty"`  // default 50, máximo 200
+	PageToken     string                 `protobuf:"bytes,7,opt,name=pageToken,proto3" json:"pageToken,omitempty"` // nextPageToken de la respuesta anterior, con los mismos filtros
+	SeriesId      string                 `protobuf:"bytes,8,opt,name=seriesId,proto3" json:"seriesId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateReservationResponse) Reset() {
-	*x = CreateReservationResponse{}
-	mi := &file_cubicles_proto_msgTypes[13]
+func (x *ListReservationsRequest) Reset() {
+	*x = ListReservationsRequest{}
+	mi := &file_cubicles_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateReservationResponse) String() string {
+func (x *ListReservationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateReservationResponse) ProtoMessage() {}
+func (*ListReservationsRequest) ProtoMessage() {}
 
-func (x *CreateReservationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cubicles_proto_msgTypes[13]
+func (x *ListReservationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -707,40 +4162,90 @@ func (x *CreateReservationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateReservationResponse.ProtoReflect.Descriptor instead.
-func (*CreateReservationResponse) Descriptor() ([]byte, []int) {
-	return file_cubicles_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ListReservationsRequest.ProtoReflect.Descriptor instead.
+func (*ListReservationsRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *CreateReservationResponse) GetRecordId() string {
+func (x *ListReservationsRequest) GetCubicleId() string {
 	if x != nil {
-		return x.RecordId
+		return x.CubicleId
 	}
 	return ""
 }
 
-type CancelReservationRequest struct {
+func (x *ListReservationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListReservationsRequest) GetStatus() []string {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ListReservationsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListReservationsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListReservationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListReservationsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListReservationsRequest) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+type ListReservationsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RecordId      string                 `protobuf:"bytes,1,opt,name=recordId,proto3" json:"recordId,omitempty"`
+	Reservations  []*Reservation         `protobuf:"bytes,1,rep,name=reservations,proto3" json:"reservations,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // vacío en la última página
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelReservationRequest) Reset() {
-	*x = CancelReservationRequest{}
-	mi := &file_cubicles_proto_msgTypes[14]
+func (x *ListReservationsResponse) Reset() {
+	*x = ListReservationsResponse{}
+	mi := &file_cubicles_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelReservationRequest) String() string {
+func (x *ListReservationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelReservationRequest) ProtoMessage() {}
+func (*ListReservationsResponse) ProtoMessage() {}
 
-func (x *CancelReservationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cubicles_proto_msgTypes[14]
+func (x *ListReservationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -751,40 +4256,146 @@ func (x *CancelReservationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelReservationRequest.ProtoReflect.Descriptor instead.
-func (*CancelReservationRequest) Descriptor() ([]byte, []int) {
-	return file_cubicles_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ListReservationsResponse.ProtoReflect.Descriptor instead.
+func (*ListReservationsResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *CancelReservationRequest) GetRecordId() string {
+func (x *ListReservationsResponse) GetReservations() []*Reservation {
 	if x != nil {
-		return x.RecordId
+		return x.Reservations
+	}
+	return nil
+}
+
+func (x *ListReservationsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
 	}
 	return ""
 }
 
-type CancelReservationResponse struct {
+// ListFreeIntervals regresa los intervalos libres del cubículo dentro de [from, to)
+// (máximo 31 días), en orden cronológico, para pintar un calendario. Ya descuenta el
+// horario de la ubicación y los cierres (GetCalendars).
+type ListFreeIntervalsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	CubicleId string                 `protobuf:"bytes,1,opt,name=cubicleId,proto3" json:"cubicleId,omitempty"`
+	From      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Opcional: recorta cada intervalo a fronteras de slot contadas desde la medianoche
+	// local y descarta los que no alcanzan un slot completo.
+	SlotDuration *durationpb.Duration `protobuf:"bytes,4,opt,name=slotDuration,proto3" json:"slotDuration,omitempty"`
+	// Opcionales: horario diario "HH:MM" en timeZone que restringe aún más el resultado;
+	// closeTime acepta "24:00".
+	OpenTime      string `protobuf:"bytes,5,opt,name=openTime,proto3" json:"openTime,omitempty"`
+	CloseTime     string `protobuf:"bytes,6,opt,name=closeTime,proto3" json:"closeTime,omitempty"`
+	TimeZone      string `protobuf:"bytes,7,opt,name=timeZone,proto3" json:"timeZone,omitempty"` // nombre IANA, default UTC
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFreeIntervalsRequest) Reset() {
+	*x = ListFreeIntervalsRequest{}
+	mi := &file_cubicles_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFreeIntervalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFreeIntervalsRequest) ProtoMessage() {}
+
+func (x *ListFreeIntervalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFreeIntervalsRequest.ProtoReflect.Descriptor instead.
+func (*ListFreeIntervalsRequest) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ListFreeIntervalsRequest) GetCubicleId() string {
+	if x != nil {
+		return x.CubicleId
+	}
+	return ""
+}
+
+func (x *ListFreeIntervalsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListFreeIntervalsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListFreeIntervalsRequest) GetSlotDuration() *durationpb.Duration {
+	if x != nil {
+		return x.SlotDuration
+	}
+	return nil
+}
+
+func (x *ListFreeIntervalsRequest) GetOpenTime() string {
+	if x != nil {
+		return x.OpenTime
+	}
+	return ""
+}
+
+func (x *ListFreeIntervalsRequest) GetCloseTime() string {
+	if x != nil {
+		return x.CloseTime
+	}
+	return ""
+}
+
+func (x *ListFreeIntervalsRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type ListFreeIntervalsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Free          []*TimeInterval        `protobuf:"bytes,1,rep,name=free,proto3" json:"free,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelReservationResponse) Reset() {
-	*x = CancelReservationResponse{}
-	mi := &file_cubicles_proto_msgTypes[15]
+func (x *ListFreeIntervalsResponse) Reset() {
+	*x = ListFreeIntervalsResponse{}
+	mi := &file_cubicles_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelReservationResponse) String() string {
+func (x *ListFreeIntervalsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelReservationResponse) ProtoMessage() {}
+func (*ListFreeIntervalsResponse) ProtoMessage() {}
 
-func (x *CancelReservationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cubicles_proto_msgTypes[15]
+func (x *ListFreeIntervalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cubicles_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -795,43 +4406,90 @@ func (x *CancelReservationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelReservationResponse.ProtoReflect.Descriptor instead.
-func (*CancelReservationResponse) Descriptor() ([]byte, []int) {
-	return file_cubicles_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ListFreeIntervalsResponse.ProtoReflect.Descriptor instead.
+func (*ListFreeIntervalsResponse) Descriptor() ([]byte, []int) {
+	return file_cubicles_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *CancelReservationResponse) GetOk() bool {
+func (x *ListFreeIntervalsResponse) GetFree() []*TimeInterval {
 	if x != nil {
-		return x.Ok
+		return x.Free
 	}
-	return false
+	return nil
 }
 
 var File_cubicles_proto protoreflect.FileDescriptor
 
 const file_cubicles_proto_rawDesc = "" +
 	"\n" +
-	"\x0ecubicles.proto\x12\bcubicles\x1a\x1fgoogle/protobuf/timestamp.proto\"f\n" +
+	"\x0ecubicles.proto\x12\bcubicles\x1a\x1egoogle/protobuf/duration.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x82\x01\n" +
 	"\bMetadata\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
 	"\blocation\x18\x03 \x01(\tR\blocation\x12\x1a\n" +
-	"\bcapacity\x18\x04 \x01(\x05R\bcapacity\"\xd9\x01\n" +
+	"\bcapacity\x18\x04 \x01(\x05R\bcapacity\x12\x1a\n" +
+	"\barchived\x18\x05 \x01(\bR\barchived\"\xb7\x03\n" +
 	"\vReservation\x12\x1a\n" +
-	"\brecordId\x18\x01 \x01(\tR\brecordId\x12\x1e\n" +
+	"\brecordId\x18\x01 \x01(\tR\brecordId\x12\"\n" +
 	"\n" +
-	"recordType\x18\x02 \x01(\tR\n" +
+	"recordType\x18\x02 \x01(\tB\x02\x18\x01R\n" +
 	"recordType\x12\x16\n" +
 	"\x06userId\x18\x03 \x01(\tR\x06userId\x120\n" +
 	"\x05start\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
 	"\x03end\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\"t\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1c\n" +
+	"\tcubicleId\x18\a \x01(\tR\tcubicleId\x12<\n" +
+	"\vcheckedInAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcheckedInAt\x12<\n" +
+	"\vcancelledAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vcancelledAt\x12\"\n" +
+	"\fcancelReason\x18\n" +
+	" \x01(\tR\fcancelReason\x12\x1a\n" +
+	"\bseriesId\x18\v \x01(\tR\bseriesId\"\xf1\x02\n" +
+	"\rWaitlistEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tcubicleId\x18\x02 \x01(\tR\tcubicleId\x12\x16\n" +
+	"\x06userId\x18\x03 \x01(\tR\x06userId\x120\n" +
+	"\x05start\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x128\n" +
+	"\tcreatedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12$\n" +
+	"\rofferRecordId\x18\b \x01(\tR\rofferRecordId\x12B\n" +
+	"\x0eofferExpiresAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0eofferExpiresAt\"\x8e\x01\n" +
 	"\fAvailability\x12\"\n" +
 	"\favailableNow\x18\x01 \x01(\bR\favailableNow\x12@\n" +
-	"\rnextAvailable\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rnextAvailable\"z\n" +
+	"\rnextAvailable\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rnextAvailable\x12\x18\n" +
+	"\aunknown\x18\x03 \x01(\bR\aunknown\"n\n" +
+	"\fTimeInterval\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"I\n" +
+	"\tItemError\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"^\n" +
+	"\bDayHours\x12\x18\n" +
+	"\aweekday\x18\x01 \x01(\x05R\aweekday\x12\x1a\n" +
+	"\bopenTime\x18\x02 \x01(\tR\bopenTime\x12\x1c\n" +
+	"\tcloseTime\x18\x03 \x01(\tR\tcloseTime\"n\n" +
+	"\fOpeningHours\x12\x1a\n" +
+	"\blocation\x18\x01 \x01(\tR\blocation\x12\x1a\n" +
+	"\btimeZone\x18\x02 \x01(\tR\btimeZone\x12&\n" +
+	"\x04days\x18\x03 \x03(\v2\x12.cubicles.DayHoursR\x04days\"\xcb\x01\n" +
+	"\aClosure\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12\x1c\n" +
+	"\tcubicleId\x18\x03 \x01(\tR\tcubicleId\x120\n" +
+	"\x05start\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\"\xc2\x01\n" +
+	"\x0fCubicleCalendar\x12\x1c\n" +
+	"\tcubicleId\x18\x01 \x01(\tR\tcubicleId\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12\x1a\n" +
+	"\btimeZone\x18\x03 \x01(\tR\btimeZone\x12*\n" +
+	"\x04open\x18\x04 \x03(\v2\x16.cubicles.TimeIntervalR\x04open\x12-\n" +
+	"\bclosures\x18\x05 \x03(\v2\x11.cubicles.ClosureR\bclosures\"\xa2\x01\n" +
 	"\x0eCubicleDetails\x12.\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x12.cubicles.MetadataR\bmetadata\x128\n" +
-	"\vreservation\x18\x02 \x01(\v2\x16.cubicles.AvailabilityR\vreservation\"2\n" +
+	"\vreservation\x18\x02 \x01(\v2\x16.cubicles.AvailabilityR\vreservation\x12&\n" +
+	"\x0edegradedReason\x18\x03 \x01(\tR\x0edegradedReason\"2\n" +
 	"\x12GetMetadataRequest\x12\x1c\n" +
 	"\tcubicleId\x18\x01 \x01(\tR\tcubicleId\"E\n" +
 	"\x13GetMetadataResponse\x12.\n" +
@@ -839,33 +4497,259 @@ const file_cubicles_proto_rawDesc = "" +
 	"\x15CreateMetadataRequest\x12.\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x12.cubicles.MetadataR\bmetadata\"6\n" +
 	"\x16CreateMetadataResponse\x12\x1c\n" +
-	"\tcubicleId\x18\x01 \x01(\tR\tcubicleId\"8\n" +
+	"\tcubicleId\x18\x01 \x01(\tR\tcubicleId\"\xf1\x01\n" +
+	"\x13ListMetadataRequest\x12\x1a\n" +
+	"\blocation\x18\x01 \x01(\tR\blocation\x12 \n" +
+	"\vminCapacity\x18\x02 \x01(\x05R\vminCapacity\x12\x1e\n" +
+	"\n" +
+	"namePrefix\x18\x03 \x01(\tR\n" +
+	"namePrefix\x12\x18\n" +
+	"\aorderBy\x18\x04 \x01(\tR\aorderBy\x12\x1a\n" +
+	"\bpageSize\x18\x05 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x06 \x01(\tR\tpageToken\x12(\n" +
+	"\x0fincludeArchived\x18\a \x01(\bR\x0fincludeArchived\"l\n" +
+	"\x14ListMetadataResponse\x12.\n" +
+	"\bmetadata\x18\x01 \x03(\v2\x12.cubicles.MetadataR\bmetadata\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"\x83\x01\n" +
+	"\x15UpdateMetadataRequest\x12.\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x12.cubicles.MetadataR\bmetadata\x12:\n" +
+	"\n" +
+	"updateMask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"H\n" +
+	"\x16UpdateMetadataResponse\x12.\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x12.cubicles.MetadataR\bmetadata\"5\n" +
+	"\x15DeleteMetadataRequest\x12\x1c\n" +
+	"\tcubicleId\x18\x01 \x01(\tR\tcubicleId\"(\n" +
+	"\x16DeleteMetadataResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\"9\n" +
+	"\x17BatchGetMetadataRequest\x12\x1e\n" +
+	"\n" +
+	"cubicleIds\x18\x01 \x03(\tR\n" +
+	"cubicleIds\"w\n" +
+	"\x18BatchGetMetadataResponse\x12.\n" +
+	"\bmetadata\x18\x01 \x03(\v2\x12.cubicles.MetadataR\bmetadata\x12+\n" +
+	"\x06errors\x18\x02 \x03(\v2\x13.cubicles.ItemErrorR\x06errors\"F\n" +
+	"\x16SetOpeningHoursRequest\x12,\n" +
+	"\x05hours\x18\x01 \x01(\v2\x16.cubicles.OpeningHoursR\x05hours\"G\n" +
+	"\x17SetOpeningHoursResponse\x12,\n" +
+	"\x05hours\x18\x01 \x01(\v2\x16.cubicles.OpeningHoursR\x05hours\"4\n" +
+	"\x16GetOpeningHoursRequest\x12\x1a\n" +
+	"\blocation\x18\x01 \x01(\tR\blocation\"G\n" +
+	"\x17GetOpeningHoursResponse\x12,\n" +
+	"\x05hours\x18\x01 \x01(\v2\x16.cubicles.OpeningHoursR\x05hours\"7\n" +
+	"\x19DeleteOpeningHoursRequest\x12\x1a\n" +
+	"\blocation\x18\x01 \x01(\tR\blocation\",\n" +
+	"\x1aDeleteOpeningHoursResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\"C\n" +
+	"\x14CreateClosureRequest\x12+\n" +
+	"\aclosure\x18\x01 \x01(\v2\x11.cubicles.ClosureR\aclosure\"D\n" +
+	"\x15CreateClosureResponse\x12+\n" +
+	"\aclosure\x18\x01 \x01(\v2\x11.cubicles.ClosureR\aclosure\"4\n" +
+	"\x14DeleteClosureRequest\x12\x1c\n" +
+	"\tclosureId\x18\x01 \x01(\tR\tclosureId\"'\n" +
+	"\x15DeleteClosureResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\"\xab\x01\n" +
+	"\x13ListClosuresRequest\x12\x1a\n" +
+	"\blocation\x18\x01 \x01(\tR\blocation\x12\x1c\n" +
+	"\tcubicleId\x18\x02 \x01(\tR\tcubicleId\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"E\n" +
+	"\x14ListClosuresResponse\x12-\n" +
+	"\bclosures\x18\x01 \x03(\v2\x11.cubicles.ClosureR\bclosures\"\x91\x01\n" +
+	"\x13GetCalendarsRequest\x12\x1e\n" +
+	"\n" +
+	"cubicleIds\x18\x01 \x03(\tR\n" +
+	"cubicleIds\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"|\n" +
+	"\x14GetCalendarsResponse\x127\n" +
+	"\tcalendars\x18\x01 \x03(\v2\x19.cubicles.CubicleCalendarR\tcalendars\x12+\n" +
+	"\x06errors\x18\x02 \x03(\v2\x13.cubicles.ItemErrorR\x06errors\"8\n" +
 	"\x18CheckAvailabilityRequest\x12\x1c\n" +
 	"\tcubicleId\x18\x01 \x01(\tR\tcubicleId\"W\n" +
 	"\x19CheckAvailabilityResponse\x12:\n" +
-	"\favailability\x18\x01 \x01(\v2\x16.cubicles.AvailabilityR\favailability\"1\n" +
+	"\favailability\x18\x01 \x01(\v2\x16.cubicles.AvailabilityR\favailability\"?\n" +
+	"\x1dBatchCheckAvailabilityRequest\x12\x1e\n" +
+	"\n" +
+	"cubicleIds\x18\x01 \x03(\tR\n" +
+	"cubicleIds\"o\n" +
+	"\x13CubicleAvailability\x12\x1c\n" +
+	"\tcubicleId\x18\x01 \x01(\tR\tcubicleId\x12:\n" +
+	"\favailability\x18\x02 \x01(\v2\x16.cubicles.AvailabilityR\favailability\"\x90\x01\n" +
+	"\x1eBatchCheckAvailabilityResponse\x12A\n" +
+	"\favailability\x18\x01 \x03(\v2\x1d.cubicles.CubicleAvailabilityR\favailability\x12+\n" +
+	"\x06errors\x18\x02 \x03(\v2\x13.cubicles.ItemErrorR\x06errors\"\x95\x01\n" +
+	"\x17FindFreeCubiclesRequest\x12\x1e\n" +
+	"\n" +
+	"cubicleIds\x18\x01 \x03(\tR\n" +
+	"cubicleIds\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\":\n" +
+	"\x18FindFreeCubiclesResponse\x12\x1e\n" +
+	"\n" +
+	"cubicleIds\x18\x01 \x03(\tR\n" +
+	"cubicleIds\"1\n" +
 	"\x11GetCubicleRequest\x12\x1c\n" +
 	"\tcubicleId\x18\x01 \x01(\tR\tcubicleId\"H\n" +
 	"\x12GetCubicleResponse\x122\n" +
-	"\adetails\x18\x01 \x01(\v2\x18.cubicles.CubicleDetailsR\adetails\"S\n" +
+	"\adetails\x18\x01 \x01(\v2\x18.cubicles.CubicleDetailsR\adetails\"\xd5\x01\n" +
+	"\x15SearchCubiclesRequest\x12\x1a\n" +
+	"\blocation\x18\x01 \x01(\tR\blocation\x12 \n" +
+	"\vminCapacity\x18\x02 \x01(\x05R\vminCapacity\x120\n" +
+	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x1e\n" +
+	"\n" +
+	"maxResults\x18\x05 \x01(\x05R\n" +
+	"maxResults\"9\n" +
+	"\x17BatchGetCubiclesRequest\x12\x1e\n" +
+	"\n" +
+	"cubicleIds\x18\x01 \x03(\tR\n" +
+	"cubicleIds\"\x8c\x01\n" +
+	"\rCubicleResult\x12\x1c\n" +
+	"\tcubicleId\x18\x01 \x01(\tR\tcubicleId\x122\n" +
+	"\adetails\x18\x02 \x01(\v2\x18.cubicles.CubicleDetailsR\adetails\x12)\n" +
+	"\x05error\x18\x03 \x01(\v2\x13.cubicles.ItemErrorR\x05error\"M\n" +
+	"\x18BatchGetCubiclesResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.cubicles.CubicleResultR\aresults\"b\n" +
+	"\x10CubicleCandidate\x12.\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x12.cubicles.MetadataR\bmetadata\x12\x1e\n" +
+	"\n" +
+	"spareSeats\x18\x02 \x01(\x05R\n" +
+	"spareSeats\"T\n" +
+	"\x16SearchCubiclesResponse\x12:\n" +
+	"\n" +
+	"candidates\x18\x01 \x03(\v2\x1a.cubicles.CubicleCandidateR\n" +
+	"candidates\"\x8f\x01\n" +
 	"\x18CreateReservationRequest\x127\n" +
-	"\vreservation\x18\x01 \x01(\v2\x15.cubicles.ReservationR\vreservation\"7\n" +
+	"\vreservation\x18\x01 \x01(\v2\x15.cubicles.ReservationR\vreservation\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x02 \x01(\tR\n" +
+	"recurrence\x12\x1a\n" +
+	"\btimeZone\x18\x03 \x01(\tR\btimeZone\"q\n" +
 	"\x19CreateReservationResponse\x12\x1a\n" +
-	"\brecordId\x18\x01 \x01(\tR\brecordId\"6\n" +
+	"\brecordId\x18\x01 \x01(\tR\brecordId\x12\x1a\n" +
+	"\bseriesId\x18\x02 \x01(\tR\bseriesId\x12\x1c\n" +
+	"\trecordIds\x18\x03 \x03(\tR\trecordIds\"\xbf\x01\n" +
+	"\x0fOccurrenceError\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x126\n" +
+	"\n" +
+	"occurrence\x18\x02 \x01(\v2\x16.cubicles.TimeIntervalR\n" +
+	"occurrence\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x120\n" +
+	"\x13conflictingRecordId\x18\x05 \x01(\tR\x13conflictingRecordId\"N\n" +
 	"\x18CancelReservationRequest\x12\x1a\n" +
-	"\brecordId\x18\x01 \x01(\tR\brecordId\"+\n" +
+	"\brecordId\x18\x01 \x01(\tR\brecordId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"d\n" +
 	"\x19CancelReservationResponse\x12\x0e\n" +
-	"\x02ok\x18\x01 \x01(\bR\x02ok2\xb2\x01\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x127\n" +
+	"\vreservation\x18\x02 \x01(\v2\x15.cubicles.ReservationR\vreservation\"y\n" +
+	"\x13CancelSeriesRequest\x12\x1a\n" +
+	"\bseriesId\x18\x01 \x01(\tR\bseriesId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\"K\n" +
+	"\x14CancelSeriesResponse\x123\n" +
+	"\tcancelled\x18\x01 \x03(\v2\x15.cubicles.ReservationR\tcancelled\"\xab\x01\n" +
+	"\x13JoinWaitlistRequest\x12\x1c\n" +
+	"\tcubicleId\x18\x01 \x01(\tR\tcubicleId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\x120\n" +
+	"\x05start\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\"E\n" +
+	"\x14JoinWaitlistResponse\x12-\n" +
+	"\x05entry\x18\x01 \x01(\v2\x17.cubicles.WaitlistEntryR\x05entry\"0\n" +
+	"\x14LeaveWaitlistRequest\x12\x18\n" +
+	"\aentryId\x18\x01 \x01(\tR\aentryId\"F\n" +
+	"\x15LeaveWaitlistResponse\x12-\n" +
+	"\x05entry\x18\x01 \x01(\v2\x17.cubicles.WaitlistEntryR\x05entry\"6\n" +
+	"\x1aAcceptWaitlistOfferRequest\x12\x18\n" +
+	"\aentryId\x18\x01 \x01(\tR\aentryId\"\x85\x01\n" +
+	"\x1bAcceptWaitlistOfferResponse\x12-\n" +
+	"\x05entry\x18\x01 \x01(\v2\x17.cubicles.WaitlistEntryR\x05entry\x127\n" +
+	"\vreservation\x18\x02 \x01(\v2\x15.cubicles.ReservationR\vreservation\"\x9d\x01\n" +
+	"\x13ListWaitlistRequest\x12\x1c\n" +
+	"\tcubicleId\x18\x01 \x01(\tR\tcubicleId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x03 \x03(\tR\x06status\x12\x1a\n" +
+	"\bpageSize\x18\x04 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x05 \x01(\tR\tpageToken\"o\n" +
+	"\x14ListWaitlistResponse\x121\n" +
+	"\aentries\x18\x01 \x03(\v2\x17.cubicles.WaitlistEntryR\aentries\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"7\n" +
+	"\x19ConfirmReservationRequest\x12\x1a\n" +
+	"\brecordId\x18\x01 \x01(\tR\brecordId\"U\n" +
+	"\x1aConfirmReservationResponse\x127\n" +
+	"\vreservation\x18\x01 \x01(\v2\x15.cubicles.ReservationR\vreservation\",\n" +
+	"\x0eCheckInRequest\x12\x1a\n" +
+	"\brecordId\x18\x01 \x01(\tR\brecordId\"J\n" +
+	"\x0fCheckInResponse\x127\n" +
+	"\vreservation\x18\x01 \x01(\v2\x15.cubicles.ReservationR\vreservation\"8\n" +
+	"\x1aCompleteReservationRequest\x12\x1a\n" +
+	"\brecordId\x18\x01 \x01(\tR\brecordId\"V\n" +
+	"\x1bCompleteReservationResponse\x127\n" +
+	"\vreservation\x18\x01 \x01(\v2\x15.cubicles.ReservationR\vreservation\"/\n" +
+	"\x11MarkNoShowRequest\x12\x1a\n" +
+	"\brecordId\x18\x01 \x01(\tR\brecordId\"M\n" +
+	"\x12MarkNoShowResponse\x127\n" +
+	"\vreservation\x18\x01 \x01(\v2\x15.cubicles.ReservationR\vreservation\"\x99\x02\n" +
+	"\x17ListReservationsRequest\x12\x1c\n" +
+	"\tcubicleId\x18\x01 \x01(\tR\tcubicleId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x03 \x03(\tR\x06status\x12.\n" +
+	"\x04from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x1a\n" +
+	"\bpageSize\x18\x06 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\a \x01(\tR\tpageToken\x12\x1a\n" +
+	"\bseriesId\x18\b \x01(\tR\bseriesId\"{\n" +
+	"\x18ListReservationsResponse\x129\n" +
+	"\freservations\x18\x01 \x03(\v2\x15.cubicles.ReservationR\freservations\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"\xa9\x02\n" +
+	"\x18ListFreeIntervalsRequest\x12\x1c\n" +
+	"\tcubicleId\x18\x01 \x01(\tR\tcubicleId\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12=\n" +
+	"\fslotDuration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fslotDuration\x12\x1a\n" +
+	"\bopenTime\x18\x05 \x01(\tR\bopenTime\x12\x1c\n" +
+	"\tcloseTime\x18\x06 \x01(\tR\tcloseTime\x12\x1a\n" +
+	"\btimeZone\x18\a \x01(\tR\btimeZone\"G\n" +
+	"\x19ListFreeIntervalsResponse\x12*\n" +
+	"\x04free\x18\x01 \x03(\v2\x16.cubicles.TimeIntervalR\x04free2\xd9\b\n" +
 	"\x0fMetadataService\x12J\n" +
 	"\vGetMetadata\x12\x1c.cubicles.GetMetadataRequest\x1a\x1d.cubicles.GetMetadataResponse\x12S\n" +
-	"\x0eCreateMetadata\x12\x1f.cubicles.CreateMetadataRequest\x1a .cubicles.CreateMetadataResponse2\xae\x02\n" +
+	"\x0eCreateMetadata\x12\x1f.cubicles.CreateMetadataRequest\x1a .cubicles.CreateMetadataResponse\x12M\n" +
+	"\fListMetadata\x12\x1d.cubicles.ListMetadataRequest\x1a\x1e.cubicles.ListMetadataResponse\x12S\n" +
+	"\x0eUpdateMetadata\x12\x1f.cubicles.UpdateMetadataRequest\x1a .cubicles.UpdateMetadataResponse\x12S\n" +
+	"\x0eDeleteMetadata\x12\x1f.cubicles.DeleteMetadataRequest\x1a .cubicles.DeleteMetadataResponse\x12Y\n" +
+	"\x10BatchGetMetadata\x12!.cubicles.BatchGetMetadataRequest\x1a\".cubicles.BatchGetMetadataResponse\x12V\n" +
+	"\x0fSetOpeningHours\x12 .cubicles.SetOpeningHoursRequest\x1a!.cubicles.SetOpeningHoursResponse\x12V\n" +
+	"\x0fGetOpeningHours\x12 .cubicles.GetOpeningHoursRequest\x1a!.cubicles.GetOpeningHoursResponse\x12_\n" +
+	"\x12DeleteOpeningHours\x12#.cubicles.DeleteOpeningHoursRequest\x1a$.cubicles.DeleteOpeningHoursResponse\x12P\n" +
+	"\rCreateClosure\x12\x1e.cubicles.CreateClosureRequest\x1a\x1f.cubicles.CreateClosureResponse\x12P\n" +
+	"\rDeleteClosure\x12\x1e.cubicles.DeleteClosureRequest\x1a\x1f.cubicles.DeleteClosureResponse\x12M\n" +
+	"\fListClosures\x12\x1d.cubicles.ListClosuresRequest\x1a\x1e.cubicles.ListClosuresResponse\x12M\n" +
+	"\fGetCalendars\x12\x1d.cubicles.GetCalendarsRequest\x1a\x1e.cubicles.GetCalendarsResponse2\xa0\v\n" +
 	"\x12ReservationService\x12\\\n" +
-	"\x11CheckAvailability\x12\".cubicles.CheckAvailabilityRequest\x1a#.cubicles.CheckAvailabilityResponse\x12\\\n" +
+	"\x11CheckAvailability\x12\".cubicles.CheckAvailabilityRequest\x1a#.cubicles.CheckAvailabilityResponse\x12k\n" +
+	"\x16BatchCheckAvailability\x12'.cubicles.BatchCheckAvailabilityRequest\x1a(.cubicles.BatchCheckAvailabilityResponse\x12Y\n" +
+	"\x10FindFreeCubicles\x12!.cubicles.FindFreeCubiclesRequest\x1a\".cubicles.FindFreeCubiclesResponse\x12\\\n" +
 	"\x11CreateReservation\x12\".cubicles.CreateReservationRequest\x1a#.cubicles.CreateReservationResponse\x12\\\n" +
-	"\x11CancelReservation\x12\".cubicles.CancelReservationRequest\x1a#.cubicles.CancelReservationResponse2Y\n" +
+	"\x11CancelReservation\x12\".cubicles.CancelReservationRequest\x1a#.cubicles.CancelReservationResponse\x12M\n" +
+	"\fCancelSeries\x12\x1d.cubicles.CancelSeriesRequest\x1a\x1e.cubicles.CancelSeriesResponse\x12_\n" +
+	"\x12ConfirmReservation\x12#.cubicles.ConfirmReservationRequest\x1a$.cubicles.ConfirmReservationResponse\x12>\n" +
+	"\aCheckIn\x12\x18.cubicles.CheckInRequest\x1a\x19.cubicles.CheckInResponse\x12b\n" +
+	"\x13CompleteReservation\x12$.cubicles.CompleteReservationRequest\x1a%.cubicles.CompleteReservationResponse\x12G\n" +
+	"\n" +
+	"MarkNoShow\x12\x1b.cubicles.MarkNoShowRequest\x1a\x1c.cubicles.MarkNoShowResponse\x12Y\n" +
+	"\x10ListReservations\x12!.cubicles.ListReservationsRequest\x1a\".cubicles.ListReservationsResponse\x12\\\n" +
+	"\x11ListFreeIntervals\x12\".cubicles.ListFreeIntervalsRequest\x1a#.cubicles.ListFreeIntervalsResponse\x12M\n" +
+	"\fJoinWaitlist\x12\x1d.cubicles.JoinWaitlistRequest\x1a\x1e.cubicles.JoinWaitlistResponse\x12P\n" +
+	"\rLeaveWaitlist\x12\x1e.cubicles.LeaveWaitlistRequest\x1a\x1f.cubicles.LeaveWaitlistResponse\x12b\n" +
+	"\x13AcceptWaitlistOffer\x12$.cubicles.AcceptWaitlistOfferRequest\x1a%.cubicles.AcceptWaitlistOfferResponse\x12M\n" +
+	"\fListWaitlist\x12\x1d.cubicles.ListWaitlistRequest\x1a\x1e.cubicles.ListWaitlistResponse2\x89\x02\n" +
 	"\x0eCubicleService\x12G\n" +
 	"\n" +
-	"GetCubicle\x12\x1b.cubicles.GetCubicleRequest\x1a\x1c.cubicles.GetCubicleResponseB\"Z cubiculosup.com/proto;cubiclespbb\x06proto3"
+	"GetCubicle\x12\x1b.cubicles.GetCubicleRequest\x1a\x1c.cubicles.GetCubicleResponse\x12S\n" +
+	"\x0eSearchCubicles\x12\x1f.cubicles.SearchCubiclesRequest\x1a .cubicles.SearchCubiclesResponse\x12Y\n" +
+	"\x10BatchGetCubicles\x12!.cubicles.BatchGetCubiclesRequest\x1a\".cubicles.BatchGetCubiclesResponseB\"Z cubiculosup.com/proto;cubiclespbb\x06proto3"
 
 var (
 	file_cubicles_proto_rawDescOnce sync.Once
@@ -879,54 +4763,236 @@ func file_cubicles_proto_rawDescGZIP() []byte {
 	return file_cubicles_proto_rawDescData
 }
 
-var file_cubicles_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_cubicles_proto_msgTypes = make([]protoimpl.MessageInfo, 79)
 var file_cubicles_proto_goTypes = []any{
-	(*Metadata)(nil),                  // 0: cubicles.Metadata
-	(*Reservation)(nil),               // 1: cubicles.Reservation
-	(*Availability)(nil),              // 2: cubicles.Availability
-	(*CubicleDetails)(nil),            // 3: cubicles.CubicleDetails
-	(*GetMetadataRequest)(nil),        // 4: cubicles.GetMetadataRequest
-	(*GetMetadataResponse)(nil),       // 5: cubicles.GetMetadataResponse
-	(*CreateMetadataRequest)(nil),     // 6: cubicles.CreateMetadataRequest
-	(*CreateMetadataResponse)(nil),    // 7: cubicles.CreateMetadataResponse
-	(*CheckAvailabilityRequest)(nil),  // 8: cubicles.CheckAvailabilityRequest
-	(*CheckAvailabilityResponse)(nil), // 9: cubicles.CheckAvailabilityResponse
-	(*GetCubicleRequest)(nil),         // 10: cubicles.GetCubicleRequest
-	(*GetCubicleResponse)(nil),        // 11: cubicles.GetCubicleResponse
-	(*CreateReservationRequest)(nil),  // 12: cubicles.CreateReservationRequest
-	(*CreateReservationResponse)(nil), // 13: cubicles.CreateReservationResponse
-	(*CancelReservationRequest)(nil),  // 14: cubicles.CancelReservationRequest
-	(*CancelReservationResponse)(nil), // 15: cubicles.CancelReservationResponse
-	(*timestamppb.Timestamp)(nil),     // 16: google.protobuf.Timestamp
+	(*Metadata)(nil),                       // 0: cubicles.Metadata
+	(*Reservation)(nil),                    // 1: cubicles.Reservation
+	(*WaitlistEntry)(nil),                  // 2: cubicles.WaitlistEntry
+	(*Availability)(nil),                   // 3: cubicles.Availability
+	(*TimeInterval)(nil),                   // 4: cubicles.TimeInterval
+	(*ItemError)(nil),                      // 5: cubicles.ItemError
+	(*DayHours)(nil),                       // 6: cubicles.DayHours
+	(*OpeningHours)(nil),                   // 7: cubicles.OpeningHours
+	(*Closure)(nil),                        // 8: cubicles.Closure
+	(*CubicleCalendar)(nil),                // 9: cubicles.CubicleCalendar
+	(*CubicleDetails)(nil),                 // 10: cubicles.CubicleDetails
+	(*GetMetadataRequest)(nil),             // 11: cubicles.GetMetadataRequest
+	(*GetMetadataResponse)(nil),            // 12: cubicles.GetMetadataResponse
+	(*CreateMetadataRequest)(nil),          // 13: cubicles.CreateMetadataRequest
+	(*CreateMetadataResponse)(nil),         // 14: cubicles.CreateMetadataResponse
+	(*ListMetadataRequest)(nil),            // 15: cubicles.ListMetadataRequest
+	(*ListMetadataResponse)(nil),           // 16: cubicles.ListMetadataResponse
+	(*UpdateMetadataRequest)(nil),          // 17: cubicles.UpdateMetadataRequest
+	(*UpdateMetadataResponse)(nil),         // 18: cubicles.UpdateMetadataResponse
+	(*DeleteMetadataRequest)(nil),          // 19: cubicles.DeleteMetadataRequest
+	(*DeleteMetadataResponse)(nil),         // 20: cubicles.DeleteMetadataResponse
+	(*BatchGetMetadataRequest)(nil),        // 21: cubicles.BatchGetMetadataRequest
+	(*BatchGetMetadataResponse)(nil),       // 22: cubicles.BatchGetMetadataResponse
+	(*SetOpeningHoursRequest)(nil),         // 23: cubicles.SetOpeningHoursRequest
+	(*SetOpeningHoursResponse)(nil),        // 24: cubicles.SetOpeningHoursResponse
+	(*GetOpeningHoursRequest)(nil),         // 25: cubicles.GetOpeningHoursRequest
+	(*GetOpeningHoursResponse)(nil),        // 26: cubicles.GetOpeningHoursResponse
+	(*DeleteOpeningHoursRequest)(nil),      // 27: cubicles.DeleteOpeningHoursRequest
+	(*DeleteOpeningHoursResponse)(nil),     // 28: cubicles.DeleteOpeningHoursResponse
+	(*CreateClosureRequest)(nil),           // 29: cubicles.CreateClosureRequest
+	(*CreateClosureResponse)(nil),          // 30: cubicles.CreateClosureResponse
+	(*DeleteClosureRequest)(nil),           // 31: cubicles.DeleteClosureRequest
+	(*DeleteClosureResponse)(nil),          // 32: cubicles.DeleteClosureResponse
+	(*ListClosuresRequest)(nil),            // 33: cubicles.ListClosuresRequest
+	(*ListClosuresResponse)(nil),           // 34: cubicles.ListClosuresResponse
+	(*GetCalendarsRequest)(nil),            // 35: cubicles.GetCalendarsRequest
+	(*GetCalendarsResponse)(nil),           // 36: cubicles.GetCalendarsResponse
+	(*CheckAvailabilityRequest)(nil),       // 37: cubicles.CheckAvailabilityRequest
+	(*CheckAvailabilityResponse)(nil),      // 38: cubicles.CheckAvailabilityResponse
+	(*BatchCheckAvailabilityRequest)(nil),  // 39: cubicles.BatchCheckAvailabilityRequest
+	(*CubicleAvailability)(nil),            // 40: cubicles.CubicleAvailability
+	(*BatchCheckAvailabilityResponse)(nil), // 41: cubicles.BatchCheckAvailabilityResponse
+	(*FindFreeCubiclesRequest)(nil),        // 42: cubicles.FindFreeCubiclesRequest
+	(*FindFreeCubiclesResponse)(nil),       // 43: cubicles.FindFreeCubiclesResponse
+	(*GetCubicleRequest)(nil),              // 44: cubicles.GetCubicleRequest
+	(*GetCubicleResponse)(nil),             // 45: cubicles.GetCubicleResponse
+	(*SearchCubiclesRequest)(nil),          // 46: cubicles.SearchCubiclesRequest
+	(*BatchGetCubiclesRequest)(nil),        // 47: cubicles.BatchGetCubiclesRequest
+	(*CubicleResult)(nil),                  // 48: cubicles.CubicleResult
+	(*BatchGetCubiclesResponse)(nil),       // 49: cubicles.BatchGetCubiclesResponse
+	(*CubicleCandidate)(nil),               // 50: cubicles.CubicleCandidate
+	(*SearchCubiclesResponse)(nil),         // 51: cubicles.SearchCubiclesResponse
+	(*CreateReservationRequest)(nil),       // 52: cubicles.CreateReservationRequest
+	(*CreateReservationResponse)(nil),      // 53: cubicles.CreateReservationResponse
+	(*OccurrenceError)(nil),                // 54: cubicles.OccurrenceError
+	(*CancelReservationRequest)(nil),       // 55: cubicles.CancelReservationRequest
+	(*CancelReservationResponse)(nil),      // 56: cubicles.CancelReservationResponse
+	(*CancelSeriesRequest)(nil),            // 57: cubicles.CancelSeriesRequest
+	(*CancelSeriesResponse)(nil),           // 58: cubicles.CancelSeriesResponse
+	(*JoinWaitlistRequest)(nil),            // 59: cubicles.JoinWaitlistRequest
+	(*JoinWaitlistResponse)(nil),           // 60: cubicles.JoinWaitlistResponse
+	(*LeaveWaitlistRequest)(nil),           // 61: cubicles.LeaveWaitlistRequest
+	(*LeaveWaitlistResponse)(nil),          // 62: cubicles.LeaveWaitlistResponse
+	(*AcceptWaitlistOfferRequest)(nil),     // 63: cubicles.AcceptWaitlistOfferRequest
+	(*AcceptWaitlistOfferResponse)(nil),    // 64: cubicles.AcceptWaitlistOfferResponse
+	(*ListWaitlistRequest)(nil),            // 65: cubicles.ListWaitlistRequest
+	(*ListWaitlistResponse)(nil),           // 66: cubicles.ListWaitlistResponse
+	(*ConfirmReservationRequest)(nil),      // 67: cubicles.ConfirmReservationRequest
+	(*ConfirmReservationResponse)(nil),     // 68: cubicles.ConfirmReservationResponse
+	(*CheckInRequest)(nil),                 // 69: cubicles.CheckInRequest
+	(*CheckInResponse)(nil),                // 70: cubicles.CheckInResponse
+	(*CompleteReservationRequest)(nil),     // 71: cubicles.CompleteReservationRequest
+	(*CompleteReservationResponse)(nil),    // 72: cubicles.CompleteReservationResponse
+	(*MarkNoShowRequest)(nil),              // 73: cubicles.MarkNoShowRequest
+	(*MarkNoShowResponse)(nil),             // 74: cubicles.MarkNoShowResponse
+	(*ListReservationsRequest)(nil),        // 75: cubicles.ListReservationsRequest
+	(*ListReservationsResponse)(nil),       // 76: cubicles.ListReservationsResponse
+	(*ListFreeIntervalsRequest)(nil),       // 77: cubicles.ListFreeIntervalsRequest
+	(*ListFreeIntervalsResponse)(nil),      // 78: cubicles.ListFreeIntervalsResponse
+	(*timestamppb.Timestamp)(nil),          // 79: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),          // 80: google.protobuf.FieldMask
+	(*durationpb.Duration)(nil),            // 81: google.protobuf.Duration
 }
 var file_cubicles_proto_depIdxs = []int32{
-	16, // 0: cubicles.Reservation.start:type_name -> google.protobuf.Timestamp
-	16, // 1: cubicles.Reservation.end:type_name -> google.protobuf.Timestamp
-	16, // 2: cubicles.Availability.nextAvailable:type_name -> google.protobuf.Timestamp
-	0,  // 3: cubicles.CubicleDetails.metadata:type_name -> cubicles.Metadata
-	2,  // 4: cubicles.CubicleDetails.reservation:type_name -> cubicles.Availability
-	0,  // 5: cubicles.GetMetadataResponse.metadata:type_name -> cubicles.Metadata
-	0,  // 6: cubicles.CreateMetadataRequest.metadata:type_name -> cubicles.Metadata
-	2,  // 7: cubicles.CheckAvailabilityResponse.availability:type_name -> cubicles.Availability
-	3,  // 8: cubicles.GetCubicleResponse.details:type_name -> cubicles.CubicleDetails
-	1,  // 9: cubicles.CreateReservationRequest.reservation:type_name -> cubicles.Reservation
-	4,  // 10: cubicles.MetadataService.GetMetadata:input_type -> cubicles.GetMetadataRequest
-	6,  // 11: cubicles.MetadataService.CreateMetadata:input_type -> cubicles.CreateMetadataRequest
-	8,  // 12: cubicles.ReservationService.CheckAvailability:input_type -> cubicles.CheckAvailabilityRequest
-	12, // 13: cubicles.ReservationService.CreateReservation:input_type -> cubicles.CreateReservationRequest
-	14, // 14: cubicles.ReservationService.CancelReservation:input_type -> cubicles.CancelReservationRequest
-	10, // 15: cubicles.CubicleService.GetCubicle:input_type -> cubicles.GetCubicleRequest
-	5,  // 16: cubicles.MetadataService.GetMetadata:output_type -> cubicles.GetMetadataResponse
-	7,  // 17: cubicles.MetadataService.CreateMetadata:output_type -> cubicles.CreateMetadataResponse
-	9,  // 18: cubicles.ReservationService.CheckAvailability:output_type -> cubicles.CheckAvailabilityResponse
-	13, // 19: cubicles.ReservationService.CreateReservation:output_type -> cubicles.CreateReservationResponse
-	15, // 20: cubicles.ReservationService.CancelReservation:output_type -> cubicles.CancelReservationResponse
-	11, // 21: cubicles.CubicleService.GetCubicle:output_type -> cubicles.GetCubicleResponse
-	16, // [16:22] is the sub-list for method output_type
-	10, // [10:16] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	79,  // 0: cubicles.Reservation.start:type_name -> google.protobuf.Timestamp
+	79,  // 1: cubicles.Reservation.end:type_name -> google.protobuf.Timestamp
+	79,  // 2: cubicles.Reservation.checkedInAt:type_name -> google.protobuf.Timestamp
+	79,  // 3: cubicles.Reservation.cancelledAt:type_name -> google.protobuf.Timestamp
+	79,  // 4: cubicles.WaitlistEntry.start:type_name -> google.protobuf.Timestamp
+	79,  // 5: cubicles.WaitlistEntry.end:type_name -> google.protobuf.Timestamp
+	79,  // 6: cubicles.WaitlistEntry.createdAt:type_name -> google.protobuf.Timestamp
+	79,  // 7: cubicles.WaitlistEntry.offerExpiresAt:type_name -> google.protobuf.Timestamp
+	79,  // 8: cubicles.Availability.nextAvailable:type_name -> google.protobuf.Timestamp
+	79,  // 9: cubicles.TimeInterval.start:type_name -> google.protobuf.Timestamp
+	79,  // 10: cubicles.TimeInterval.end:type_name -> google.protobuf.Timestamp
+	6,   // 11: cubicles.OpeningHours.days:type_name -> cubicles.DayHours
+	79,  // 12: cubicles.Closure.start:type_name -> google.protobuf.Timestamp
+	79,  // 13: cubicles.Closure.end:type_name -> google.protobuf.Timestamp
+	4,   // 14: cubicles.CubicleCalendar.open:type_name -> cubicles.TimeInterval
+	8,   // 15: cubicles.CubicleCalendar.closures:type_name -> cubicles.Closure
+	0,   // 16: cubicles.CubicleDetails.metadata:type_name -> cubicles.Metadata
+	3,   // 17: cubicles.CubicleDetails.reservation:type_name -> cubicles.Availability
+	0,   // 18: cubicles.GetMetadataResponse.metadata:type_name -> cubicles.Metadata
+	0,   // 19: cubicles.CreateMetadataRequest.metadata:type_name -> cubicles.Metadata
+	0,   // 20: cubicles.ListMetadataResponse.metadata:type_name -> cubicles.Metadata
+	0,   // 21: cubicles.UpdateMetadataRequest.metadata:type_name -> cubicles.Metadata
+	80,  // 22: cubicles.UpdateMetadataRequest.updateMask:type_name -> google.protobuf.FieldMask
+	0,   // 23: cubicles.UpdateMetadataResponse.metadata:type_name -> cubicles.Metadata
+	0,   // 24: cubicles.BatchGetMetadataResponse.metadata:type_name -> cubicles.Metadata
+	5,   // 25: cubicles.BatchGetMetadataResponse.errors:type_name -> cubicles.ItemError
+	7,   // 26: cubicles.SetOpeningHoursRequest.hours:type_name -> cubicles.OpeningHours
+	7,   // 27: cubicles.SetOpeningHoursResponse.hours:type_name -> cubicles.OpeningHours
+	7,   // 28: cubicles.GetOpeningHoursResponse.hours:type_name -> cubicles.OpeningHours
+	8,   // 29: cubicles.CreateClosureRequest.closure:type_name -> cubicles.Closure
+	8,   // 30: cubicles.CreateClosureResponse.closure:type_name -> cubicles.Closure
+	79,  // 31: cubicles.ListClosuresRequest.from:type_name -> google.protobuf.Timestamp
+	79,  // 32: cubicles.ListClosuresRequest.to:type_name -> google.protobuf.Timestamp
+	8,   // 33: cubicles.ListClosuresResponse.closures:type_name -> cubicles.Closure
+	79,  // 34: cubicles.GetCalendarsRequest.from:type_name -> google.protobuf.Timestamp
+	79,  // 35: cubicles.GetCalendarsRequest.to:type_name -> google.protobuf.Timestamp
+	9,   // 36: cubicles.GetCalendarsResponse.calendars:type_name -> cubicles.CubicleCalendar
+	5,   // 37: cubicles.GetCalendarsResponse.errors:type_name -> cubicles.ItemError
+	3,   // 38: cubicles.CheckAvailabilityResponse.availability:type_name -> cubicles.Availability
+	3,   // 39: cubicles.CubicleAvailability.availability:type_name -> cubicles.Availability
+	40,  // 40: cubicles.BatchCheckAvailabilityResponse.availability:type_name -> cubicles.CubicleAvailability
+	5,   // 41: cubicles.BatchCheckAvailabilityResponse.errors:type_name -> cubicles.ItemError
+	79,  // 42: cubicles.FindFreeCubiclesRequest.from:type_name -> google.protobuf.Timestamp
+	79,  // 43: cubicles.FindFreeCubiclesRequest.to:type_name -> google.protobuf.Timestamp
+	10,  // 44: cubicles.GetCubicleResponse.details:type_name -> cubicles.CubicleDetails
+	79,  // 45: cubicles.SearchCubiclesRequest.start:type_name -> google.protobuf.Timestamp
+	79,  // 46: cubicles.SearchCubiclesRequest.end:type_name -> google.protobuf.Timestamp
+	10,  // 47: cubicles.CubicleResult.details:type_name -> cubicles.CubicleDetails
+	5,   // 48: cubicles.CubicleResult.error:type_name -> cubicles.ItemError
+	48,  // 49: cubicles.BatchGetCubiclesResponse.results:type_name -> cubicles.CubicleResult
+	0,   // 50: cubicles.CubicleCandidate.metadata:type_name -> cubicles.Metadata
+	50,  // 51: cubicles.SearchCubiclesResponse.candidates:type_name -> cubicles.CubicleCandidate
+	1,   // 52: cubicles.CreateReservationRequest.reservation:type_name -> cubicles.Reservation
+	4,   // 53: cubicles.OccurrenceError.occurrence:type_name -> cubicles.TimeInterval
+	1,   // 54: cubicles.CancelReservationResponse.reservation:type_name -> cubicles.Reservation
+	79,  // 55: cubicles.CancelSeriesRequest.from:type_name -> google.protobuf.Timestamp
+	1,   // 56: cubicles.CancelSeriesResponse.cancelled:type_name -> cubicles.Reservation
+	79,  // 57: cubicles.JoinWaitlistRequest.start:type_name -> google.protobuf.Timestamp
+	79,  // 58: cubicles.JoinWaitlistRequest.end:type_name -> google.protobuf.Timestamp
+	2,   // 59: cubicles.JoinWaitlistResponse.entry:type_name -> cubicles.WaitlistEntry
+	2,   // 60: cubicles.LeaveWaitlistResponse.entry:type_name -> cubicles.WaitlistEntry
+	2,   // 61: cubicles.AcceptWaitlistOfferResponse.entry:type_name -> cubicles.WaitlistEntry
+	1,   // 62: cubicles.AcceptWaitlistOfferResponse.reservation:type_name -> cubicles.Reservation
+	2,   // 63: cubicles.ListWaitlistResponse.entries:type_name -> cubicles.WaitlistEntry
+	1,   // 64: cubicles.ConfirmReservationResponse.reservation:type_name -> cubicles.Reservation
+	1,   // 65: cubicles.CheckInResponse.reservation:type_name -> cubicles.Reservation
+	1,   // 66: cubicles.CompleteReservationResponse.reservation:type_name -> cubicles.Reservation
+	1,   // 67: cubicles.MarkNoShowResponse.reservation:type_name -> cubicles.Reservation
+	79,  // 68: cubicles.ListReservationsRequest.from:type_name -> google.protobuf.Timestamp
+	79,  // 69: cubicles.ListReservationsRequest.to:type_name -> google.protobuf.Timestamp
+	1,   // 70: cubicles.ListReservationsResponse.reservations:type_name -> cubicles.Reservation
+	79,  // 71: cubicles.ListFreeIntervalsRequest.from:type_name -> google.protobuf.Timestamp
+	79,  // 72: cubicles.ListFreeIntervalsRequest.to:type_name -> google.protobuf.Timestamp
+	81,  // 73: cubicles.ListFreeIntervalsRequest.slotDuration:type_name -> google.protobuf.Duration
+	4,   // 74: cubicles.ListFreeIntervalsResponse.free:type_name -> cubicles.TimeInterval
+	11,  // 75: cubicles.MetadataService.GetMetadata:input_type -> cubicles.GetMetadataRequest
+	13,  // 76: cubicles.MetadataService.CreateMetadata:input_type -> cubicles.CreateMetadataRequest
+	15,  // 77: cubicles.MetadataService.ListMetadata:input_type -> cubicles.ListMetadataRequest
+	17,  // 78: cubicles.MetadataService.UpdateMetadata:input_type -> cubicles.UpdateMetadataRequest
+	19,  // 79: cubicles.MetadataService.DeleteMetadata:input_type -> cubicles.DeleteMetadataRequest
+	21,  // 80: cubicles.MetadataService.BatchGetMetadata:input_type -> cubicles.BatchGetMetadataRequest
+	23,  // 81: cubicles.MetadataService.SetOpeningHours:input_type -> cubicles.SetOpeningHoursRequest
+	25,  // 82: cubicles.MetadataService.GetOpeningHours:input_type -> cubicles.GetOpeningHoursRequest
+	27,  // 83: cubicles.MetadataService.DeleteOpeningHours:input_type -> cubicles.DeleteOpeningHoursRequest
+	29,  // 84: cubicles.MetadataService.CreateClosure:input_type -> cubicles.CreateClosureRequest
+	31,  // 85: cubicles.MetadataService.DeleteClosure:input_type -> cubicles.DeleteClosureRequest
+	33,  // 86: cubicles.MetadataService.ListClosures:input_type -> cubicles.ListClosuresRequest
+	35,  // 87: cubicles.MetadataService.GetCalendars:input_type -> cubicles.GetCalendarsRequest
+	37,  // 88: cubicles.ReservationService.CheckAvailability:input_type -> cubicles.CheckAvailabilityRequest
+	39,  // 89: cubicles.ReservationService.BatchCheckAvailability:input_type -> cubicles.BatchCheckAvailabilityRequest
+	42,  // 90: cubicles.ReservationService.FindFreeCubicles:input_type -> cubicles.FindFreeCubiclesRequest
+	52,  // 91: cubicles.ReservationService.CreateReservation:input_type -> cubicles.CreateReservationRequest
+	55,  // 92: cubicles.ReservationService.CancelReservation:input_type -> cubicles.CancelReservationRequest
+	57,  // 93: cubicles.ReservationService.CancelSeries:input_type -> cubicles.CancelSeriesRequest
+	67,  // 94: cubicles.ReservationService.ConfirmReservation:input_type -> cubicles.ConfirmReservationRequest
+	69,  // 95: cubicles.ReservationService.CheckIn:input_type -> cubicles.CheckInRequest
+	71,  // 96: cubicles.ReservationService.CompleteReservation:input_type -> cubicles.CompleteReservationRequest
+	73,  // 97: cubicles.ReservationService.MarkNoShow:input_type -> cubicles.MarkNoShowRequest
+	75,  // 98: cubicles.ReservationService.ListReservations:input_type -> cubicles.ListReservationsRequest
+	77,  // 99: cubicles.ReservationService.ListFreeIntervals:input_type -> cubicles.ListFreeIntervalsRequest
+	59,  // 100: cubicles.ReservationService.JoinWaitlist:input_type -> cubicles.JoinWaitlistRequest
+	61,  // 101: cubicles.ReservationService.LeaveWaitlist:input_type -> cubicles.LeaveWaitlistRequest
+	63,  // 102: cubicles.ReservationService.AcceptWaitlistOffer:input_type -> cubicles.AcceptWaitlistOfferRequest
+	65,  // 103: cubicles.ReservationService.ListWaitlist:input_type -> cubicles.ListWaitlistRequest
+	44,  // 104: cubicles.CubicleService.GetCubicle:input_type -> cubicles.GetCubicleRequest
+	46,  // 105: cubicles.CubicleService.SearchCubicles:input_type -> cubicles.SearchCubiclesRequest
+	47,  // 106: cubicles.CubicleService.BatchGetCubicles:input_type -> cubicles.BatchGetCubiclesRequest
+	12,  // 107: cubicles.MetadataService.GetMetadata:output_type -> cubicles.GetMetadataResponse
+	14,  // 108: cubicles.MetadataService.CreateMetadata:output_type -> cubicles.CreateMetadataResponse
+	16,  // 109: cubicles.MetadataService.ListMetadata:output_type -> cubicles.ListMetadataResponse
+	18,  // 110: cubicles.MetadataService.UpdateMetadata:output_type -> cubicles.UpdateMetadataResponse
+	20,  // 111: cubicles.MetadataService.DeleteMetadata:output_type -> cubicles.DeleteMetadataResponse
+	22,  // 112: cubicles.MetadataService.BatchGetMetadata:output_type -> cubicles.BatchGetMetadataResponse
+	24,  // 113: cubicles.MetadataService.SetOpeningHours:output_type -> cubicles.SetOpeningHoursResponse
+	26,  // 114: cubicles.MetadataService.GetOpeningHours:output_type -> cubicles.GetOpeningHoursResponse
+	28,  // 115: cubicles.MetadataService.DeleteOpeningHours:output_type -> cubicles.DeleteOpeningHoursResponse
+	30,  // 116: cubicles.MetadataService.CreateClosure:output_type -> cubicles.CreateClosureResponse
+	32,  // 117: cubicles.MetadataService.DeleteClosure:output_type -> cubicles.DeleteClosureResponse
+	34,  // 118: cubicles.MetadataService.ListClosures:output_type -> cubicles.ListClosuresResponse
+	36,  // 119: cubicles.MetadataService.GetCalendars:output_type -> cubicles.GetCalendarsResponse
+	38,  // 120: cubicles.ReservationService.CheckAvailability:output_type -> cubicles.CheckAvailabilityResponse
+	41,  // 121: cubicles.ReservationService.BatchCheckAvailability:output_type -> cubicles.BatchCheckAvailabilityResponse
+	43,  // 122: cubicles.ReservationService.FindFreeCubicles:output_type -> cubicles.FindFreeCubiclesResponse
+	53,  // 123: cubicles.ReservationService.CreateReservation:output_type -> cubicles.CreateReservationResponse
+	56,  // 124: cubicles.ReservationService.CancelReservation:output_type -> cubicles.CancelReservationResponse
+	58,  // 125: cubicles.ReservationService.CancelSeries:output_type -> cubicles.CancelSeriesResponse
+	68,  // 126: cubicles.ReservationService.ConfirmReservation:output_type -> cubicles.ConfirmReservationResponse
+	70,  // 127: cubicles.ReservationService.CheckIn:output_type -> cubicles.CheckInResponse
+	72,  // 128: cubicles.ReservationService.CompleteReservation:output_type -> cubicles.CompleteReservationResponse
+	74,  // 129: cubicles.ReservationService.MarkNoShow:output_type -> cubicles.MarkNoShowResponse
+	76,  // 130: cubicles.ReservationService.ListReservations:output_type -> cubicles.ListReservationsResponse
+	78,  // 131: cubicles.ReservationService.ListFreeIntervals:output_type -> cubicles.ListFreeIntervalsResponse
+	60,  // 132: cubicles.ReservationService.JoinWaitlist:output_type -> cubicles.JoinWaitlistResponse
+	62,  // 133: cubicles.ReservationService.LeaveWaitlist:output_type -> cubicles.LeaveWaitlistResponse
+	64,  // 134: cubicles.ReservationService.AcceptWaitlistOffer:output_type -> cubicles.AcceptWaitlistOfferResponse
+	66,  // 135: cubicles.ReservationService.ListWaitlist:output_type -> cubicles.ListWaitlistResponse
+	45,  // 136: cubicles.CubicleService.GetCubicle:output_type -> cubicles.GetCubicleResponse
+	51,  // 137: cubicles.CubicleService.SearchCubicles:output_type -> cubicles.SearchCubiclesResponse
+	49,  // 138: cubicles.CubicleService.BatchGetCubicles:output_type -> cubicles.BatchGetCubiclesResponse
+	107, // [107:139] is the sub-list for method output_type
+	75,  // [75:107] is the sub-list for method input_type
+	75,  // [75:75] is the sub-list for extension type_name
+	75,  // [75:75] is the sub-list for extension extendee
+	0,   // [0:75] is the sub-list for field type_name
 }
 
 func init() { file_cubicles_proto_init() }
@@ -940,7 +5006,7 @@ func file_cubicles_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cubicles_proto_rawDesc), len(file_cubicles_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   79,
 			NumExtensions: 0,
 			NumServices:   3,
 		},
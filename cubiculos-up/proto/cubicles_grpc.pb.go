@@ -19,8 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MetadataService_GetMetadata_FullMethodName    = "/cubicles.MetadataService/GetMetadata"
-	MetadataService_CreateMetadata_FullMethodName = "/cubicles.MetadataService/CreateMetadata"
+	MetadataService_GetMetadata_FullMethodName        = "/cubicles.MetadataService/GetMetadata"
+	MetadataService_CreateMetadata_FullMethodName     = "/cubicles.MetadataService/CreateMetadata"
+	MetadataService_ListMetadata_FullMethodName       = "/cubicles.MetadataService/ListMetadata"
+	MetadataService_UpdateMetadata_FullMethodName     = "/cubicles.MetadataService/UpdateMetadata"
+	MetadataService_DeleteMetadata_FullMethodName     = "/cubicles.MetadataService/DeleteMetadata"
+	MetadataService_BatchGetMetadata_FullMethodName   = "/cubicles.MetadataService/BatchGetMetadata"
+	MetadataService_SetOpeningHours_FullMethodName    = "/cubicles.MetadataService/SetOpeningHours"
+	MetadataService_GetOpeningHours_FullMethodName    = "/cubicles.MetadataService/GetOpeningHours"
+	MetadataService_DeleteOpeningHours_FullMethodName = "/cubicles.MetadataService/DeleteOpeningHours"
+	MetadataService_CreateClosure_FullMethodName      = "/cubicles.MetadataService/CreateClosure"
+	MetadataService_DeleteClosure_FullMethodName      = "/cubicles.MetadataService/DeleteClosure"
+	MetadataService_ListClosures_FullMethodName       = "/cubicles.MetadataService/ListClosures"
+	MetadataService_GetCalendars_FullMethodName       = "/cubicles.MetadataService/GetCalendars"
 )
 
 // MetadataServiceClient is the client API for MetadataService service.
@@ -29,6 +40,18 @@ const (
 type MetadataServiceClient interface {
 	GetMetadata(ctx context.Context, in *GetMetadataRequest, opts ...grpc.CallOption) (*GetMetadataResponse, error)
 	CreateMetadata(ctx context.Context, in *CreateMetadataRequest, opts ...grpc.CallOption) (*CreateMetadataResponse, error)
+	ListMetadata(ctx context.Context, in *ListMetadataRequest, opts ...grpc.CallOption) (*ListMetadataResponse, error)
+	UpdateMetadata(ctx context.Context, in *UpdateMetadataRequest, opts ...grpc.CallOption) (*UpdateMetadataResponse, error)
+	DeleteMetadata(ctx context.Context, in *DeleteMetadataRequest, opts ...grpc.CallOption) (*DeleteMetadataResponse, error)
+	BatchGetMetadata(ctx context.Context, in *BatchGetMetadataRequest, opts ...grpc.CallOption) (*BatchGetMetadataResponse, error)
+	// Calendario: horario por ubicación y cierres por ubicación o cubículo
+	SetOpeningHours(ctx context.Context, in *SetOpeningHoursRequest, opts ...grpc.CallOption) (*SetOpeningHoursResponse, error)
+	GetOpeningHours(ctx context.Context, in *GetOpeningHoursRequest, opts ...grpc.CallOption) (*GetOpeningHoursResponse, error)
+	DeleteOpeningHours(ctx context.Context, in *DeleteOpeningHoursRequest, opts ...grpc.CallOption) (*DeleteOpeningHoursResponse, error)
+	CreateClosure(ctx context.Context, in *CreateClosureRequest, opts ...grpc.CallOption) (*CreateClosureResponse, error)
+	DeleteClosure(ctx context.Context, in *DeleteClosureRequest, opts ...grpc.CallOption) (*DeleteClosureResponse, error)
+	ListClosures(ctx context.Context, in *ListClosuresRequest, opts ...grpc.CallOption) (*ListClosuresResponse, error)
+	GetCalendars(ctx context.Context, in *GetCalendarsRequest, opts ...grpc.CallOption) (*GetCalendarsResponse, error)
 }
 
 type metadataServiceClient struct {
@@ -59,12 +82,134 @@ func (c *metadataServiceClient) CreateMetadata(ctx context.Context, in *CreateMe
 	return out, nil
 }
 
+func (c *metadataServiceClient) ListMetadata(ctx context.Context, in *ListMetadataRequest, opts ...grpc.CallOption) (*ListMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMetadataResponse)
+	err := c.cc.Invoke(ctx, MetadataService_ListMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) UpdateMetadata(ctx context.Context, in *UpdateMetadataRequest, opts ...grpc.CallOption) (*UpdateMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateMetadataResponse)
+	err := c.cc.Invoke(ctx, MetadataService_UpdateMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) DeleteMetadata(ctx context.Context, in *DeleteMetadataRequest, opts ...grpc.CallOption) (*DeleteMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMetadataResponse)
+	err := c.cc.Invoke(ctx, MetadataService_DeleteMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) BatchGetMetadata(ctx context.Context, in *BatchGetMetadataRequest, opts ...grpc.CallOption) (*BatchGetMetadataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetMetadataResponse)
+	err := c.cc.Invoke(ctx, MetadataService_BatchGetMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) SetOpeningHours(ctx context.Context, in *SetOpeningHoursRequest, opts ...grpc.CallOption) (*SetOpeningHoursResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetOpeningHoursResponse)
+	err := c.cc.Invoke(ctx, MetadataService_SetOpeningHours_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) GetOpeningHours(ctx context.Context, in *GetOpeningHoursRequest, opts ...grpc.CallOption) (*GetOpeningHoursResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOpeningHoursResponse)
+	err := c.cc.Invoke(ctx, MetadataService_GetOpeningHours_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) DeleteOpeningHours(ctx context.Context, in *DeleteOpeningHoursRequest, opts ...grpc.CallOption) (*DeleteOpeningHoursResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteOpeningHoursResponse)
+	err := c.cc.Invoke(ctx, MetadataService_DeleteOpeningHours_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) CreateClosure(ctx context.Context, in *CreateClosureRequest, opts ...grpc.CallOption) (*CreateClosureResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateClosureResponse)
+	err := c.cc.Invoke(ctx, MetadataService_CreateClosure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) DeleteClosure(ctx context.Context, in *DeleteClosureRequest, opts ...grpc.CallOption) (*DeleteClosureResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteClosureResponse)
+	err := c.cc.Invoke(ctx, MetadataService_DeleteClosure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) ListClosures(ctx context.Context, in *ListClosuresRequest, opts ...grpc.CallOption) (*ListClosuresResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListClosuresResponse)
+	err := c.cc.Invoke(ctx, MetadataService_ListClosures_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataServiceClient) GetCalendars(ctx context.Context, in *GetCalendarsRequest, opts ...grpc.CallOption) (*GetCalendarsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCalendarsResponse)
+	err := c.cc.Invoke(ctx, MetadataService_GetCalendars_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MetadataServiceServer is the server API for MetadataService service.
 // All implementations must embed UnimplementedMetadataServiceServer
 // for forward compatibility.
 type MetadataServiceServer interface {
 	GetMetadata(context.Context, *GetMetadataRequest) (*GetMetadataResponse, error)
 	CreateMetadata(context.Context, *CreateMetadataRequest) (*CreateMetadataResponse, error)
+	ListMetadata(context.Context, *ListMetadataRequest) (*ListMetadataResponse, error)
+	UpdateMetadata(context.Context, *UpdateMetadataRequest) (*UpdateMetadataResponse, error)
+	DeleteMetadata(context.Context, *DeleteMetadataRequest) (*DeleteMetadataResponse, error)
+	BatchGetMetadata(context.Context, *BatchGetMetadataRequest) (*BatchGetMetadataResponse, error)
+	// Calendario: horario por ubicación y cierres por ubicación o cubículo
+	SetOpeningHours(context.Context, *SetOpeningHoursRequest) (*SetOpeningHoursResponse, error)
+	GetOpeningHours(context.Context, *GetOpeningHoursRequest) (*GetOpeningHoursResponse, error)
+	DeleteOpeningHours(context.Context, *DeleteOpeningHoursRequest) (*DeleteOpeningHoursResponse, error)
+	CreateClosure(context.Context, *CreateClosureRequest) (*CreateClosureResponse, error)
+	DeleteClosure(context.Context, *DeleteClosureRequest) (*DeleteClosureResponse, error)
+	ListClosures(context.Context, *ListClosuresRequest) (*ListClosuresResponse, error)
+	GetCalendars(context.Context, *GetCalendarsRequest) (*GetCalendarsResponse, error)
 	mustEmbedUnimplementedMetadataServiceServer()
 }
 
@@ -81,6 +226,39 @@ func (UnimplementedMetadataServiceServer) GetMetadata(context.Context, *GetMetad
 func (UnimplementedMetadataServiceServer) CreateMetadata(context.Context, *CreateMetadataRequest) (*CreateMetadataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateMetadata not implemented")
 }
+func (UnimplementedMetadataServiceServer) ListMetadata(context.Context, *ListMetadataRequest) (*ListMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMetadata not implemented")
+}
+func (UnimplementedMetadataServiceServer) UpdateMetadata(context.Context, *UpdateMetadataRequest) (*UpdateMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMetadata not implemented")
+}
+func (UnimplementedMetadataServiceServer) DeleteMetadata(context.Context, *DeleteMetadataRequest) (*DeleteMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMetadata not implemented")
+}
+func (UnimplementedMetadataServiceServer) BatchGetMetadata(context.Context, *BatchGetMetadataRequest) (*BatchGetMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetMetadata not implemented")
+}
+func (UnimplementedMetadataServiceServer) SetOpeningHours(context.Context, *SetOpeningHoursRequest) (*SetOpeningHoursResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOpeningHours not implemented")
+}
+func (UnimplementedMetadataServiceServer) GetOpeningHours(context.Context, *GetOpeningHoursRequest) (*GetOpeningHoursResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOpeningHours not implemented")
+}
+func (UnimplementedMetadataServiceServer) DeleteOpeningHours(context.Context, *DeleteOpeningHoursRequest) (*DeleteOpeningHoursResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOpeningHours not implemented")
+}
+func (UnimplementedMetadataServiceServer) CreateClosure(context.Context, *CreateClosureRequest) (*CreateClosureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateClosure not implemented")
+}
+func (UnimplementedMetadataServiceServer) DeleteClosure(context.Context, *DeleteClosureRequest) (*DeleteClosureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteClosure not implemented")
+}
+func (UnimplementedMetadataServiceServer) ListClosures(context.Context, *ListClosuresRequest) (*ListClosuresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClosures not implemented")
+}
+func (UnimplementedMetadataServiceServer) GetCalendars(context.Context, *GetCalendarsRequest) (*GetCalendarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCalendars not implemented")
+}
 func (UnimplementedMetadataServiceServer) mustEmbedUnimplementedMetadataServiceServer() {}
 func (UnimplementedMetadataServiceServer) testEmbeddedByValue()                         {}
 
@@ -138,6 +316,204 @@ func _MetadataService_CreateMetadata_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MetadataService_ListMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).ListMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_ListMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).ListMetadata(ctx, req.(*ListMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_UpdateMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).UpdateMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_UpdateMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).UpdateMetadata(ctx, req.(*UpdateMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_DeleteMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).DeleteMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_DeleteMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).DeleteMetadata(ctx, req.(*DeleteMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_BatchGetMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).BatchGetMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_BatchGetMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).BatchGetMetadata(ctx, req.(*BatchGetMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_SetOpeningHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOpeningHoursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).SetOpeningHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_SetOpeningHours_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).SetOpeningHours(ctx, req.(*SetOpeningHoursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_GetOpeningHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOpeningHoursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).GetOpeningHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_GetOpeningHours_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).GetOpeningHours(ctx, req.(*GetOpeningHoursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_DeleteOpeningHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOpeningHoursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).DeleteOpeningHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_DeleteOpeningHours_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).DeleteOpeningHours(ctx, req.(*DeleteOpeningHoursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_CreateClosure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateClosureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).CreateClosure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_CreateClosure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).CreateClosure(ctx, req.(*CreateClosureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_DeleteClosure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteClosureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).DeleteClosure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_DeleteClosure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).DeleteClosure(ctx, req.(*DeleteClosureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_ListClosures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListClosuresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).ListClosures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_ListClosures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).ListClosures(ctx, req.(*ListClosuresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataService_GetCalendars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCalendarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataServiceServer).GetCalendars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MetadataService_GetCalendars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataServiceServer).GetCalendars(ctx, req.(*GetCalendarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MetadataService_ServiceDesc is the grpc.ServiceDesc for MetadataService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,15 +529,72 @@ var MetadataService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateMetadata",
 			Handler:    _MetadataService_CreateMetadata_Handler,
 		},
+		{
+			MethodName: "ListMetadata",
+			Handler:    _MetadataService_ListMetadata_Handler,
+		},
+		{
+			MethodName: "UpdateMetadata",
+			Handler:    _MetadataService_UpdateMetadata_Handler,
+		},
+		{
+			MethodName: "DeleteMetadata",
+			Handler:    _MetadataService_DeleteMetadata_Handler,
+		},
+		{
+			MethodName: "BatchGetMetadata",
+			Handler:    _MetadataService_BatchGetMetadata_Handler,
+		},
+		{
+			MethodName: "SetOpeningHours",
+			Handler:    _MetadataService_SetOpeningHours_Handler,
+		},
+		{
+			MethodName: "GetOpeningHours",
+			Handler:    _MetadataService_GetOpeningHours_Handler,
+		},
+		{
+			MethodName: "DeleteOpeningHours",
+			Handler:    _MetadataService_DeleteOpeningHours_Handler,
+		},
+		{
+			MethodName: "CreateClosure",
+			Handler:    _MetadataService_CreateClosure_Handler,
+		},
+		{
+			MethodName: "DeleteClosure",
+			Handler:    _MetadataService_DeleteClosure_Handler,
+		},
+		{
+			MethodName: "ListClosures",
+			Handler:    _MetadataService_ListClosures_Handler,
+		},
+		{
+			MethodName: "GetCalendars",
+			Handler:    _MetadataService_GetCalendars_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cubicles.proto",
 }
 
 const (
-	ReservationService_CheckAvailability_FullMethodName = "/cubicles.ReservationService/CheckAvailability"
-	ReservationService_CreateReservation_FullMethodName = "/cubicles.ReservationService/CreateReservation"
-	ReservationService_CancelReservation_FullMethodName = "/cubicles.ReservationService/CancelReservation"
+	ReservationService_CheckAvailability_FullMethodName      = "/cubicles.ReservationService/CheckAvailability"
+	ReservationService_BatchCheckAvailability_FullMethodName = "/cubicles.ReservationService/BatchCheckAvailability"
+	ReservationService_FindFreeCubicles_FullMethodName       = "/cubicles.ReservationService/FindFreeCubicles"
+	ReservationService_CreateReservation_FullMethodName      = "/cubicles.ReservationService/CreateReservation"
+	ReservationService_CancelReservation_FullMethodName      = "/cubicles.ReservationService/CancelReservation"
+	ReservationService_CancelSeries_FullMethodName           = "/cubicles.ReservationService/CancelSeries"
+	ReservationService_ConfirmReservation_FullMethodName     = "/cubicles.ReservationService/ConfirmReservation"
+	ReservationService_CheckIn_FullMethodName                = "/cubicles.ReservationService/CheckIn"
+	ReservationService_CompleteReservation_FullMethodName    = "/cubicles.ReservationService/CompleteReservation"
+	ReservationService_MarkNoShow_FullMethodName             = "/cubicles.ReservationService/MarkNoShow"
+	ReservationService_ListReservations_FullMethodName       = "/cubicles.ReservationService/ListReservations"
+	ReservationService_ListFreeIntervals_FullMethodName      = "/cubicles.ReservationService/ListFreeIntervals"
+	ReservationService_JoinWaitlist_FullMethodName           = "/cubicles.ReservationService/JoinWaitlist"
+	ReservationService_LeaveWaitlist_FullMethodName          = "/cubicles.ReservationService/LeaveWaitlist"
+	ReservationService_AcceptWaitlistOffer_FullMethodName    = "/cubicles.ReservationService/AcceptWaitlistOffer"
+	ReservationService_ListWaitlist_FullMethodName           = "/cubicles.ReservationService/ListWaitlist"
 )
 
 // ReservationServiceClient is the client API for ReservationService service.
@@ -169,8 +602,22 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ReservationServiceClient interface {
 	CheckAvailability(ctx context.Context, in *CheckAvailabilityRequest, opts ...grpc.CallOption) (*CheckAvailabilityResponse, error)
+	BatchCheckAvailability(ctx context.Context, in *BatchCheckAvailabilityRequest, opts ...grpc.CallOption) (*BatchCheckAvailabilityResponse, error)
+	FindFreeCubicles(ctx context.Context, in *FindFreeCubiclesRequest, opts ...grpc.CallOption) (*FindFreeCubiclesResponse, error)
 	CreateReservation(ctx context.Context, in *CreateReservationRequest, opts ...grpc.CallOption) (*CreateReservationResponse, error)
 	CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*CancelReservationResponse, error)
+	CancelSeries(ctx context.Context, in *CancelSeriesRequest, opts ...grpc.CallOption) (*CancelSeriesResponse, error)
+	ConfirmReservation(ctx context.Context, in *ConfirmReservationRequest, opts ...grpc.CallOption) (*ConfirmReservationResponse, error)
+	CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*CheckInResponse, error)
+	CompleteReservation(ctx context.Context, in *CompleteReservationRequest, opts ...grpc.CallOption) (*CompleteReservationResponse, error)
+	MarkNoShow(ctx context.Context, in *MarkNoShowRequest, opts ...grpc.CallOption) (*MarkNoShowResponse, error)
+	ListReservations(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error)
+	ListFreeIntervals(ctx context.Context, in *ListFreeIntervalsRequest, opts ...grpc.CallOption) (*ListFreeIntervalsResponse, error)
+	// Lista de espera para intervalos ocupados
+	JoinWaitlist(ctx context.Context, in *JoinWaitlistRequest, opts ...grpc.CallOption) (*JoinWaitlistResponse, error)
+	LeaveWaitlist(ctx context.Context, in *LeaveWaitlistRequest, opts ...grpc.CallOption) (*LeaveWaitlistResponse, error)
+	AcceptWaitlistOffer(ctx context.Context, in *AcceptWaitlistOfferRequest, opts ...grpc.CallOption) (*AcceptWaitlistOfferResponse, error)
+	ListWaitlist(ctx context.Context, in *ListWaitlistRequest, opts ...grpc.CallOption) (*ListWaitlistResponse, error)
 }
 
 type reservationServiceClient struct {
@@ -191,6 +638,26 @@ func (c *reservationServiceClient) CheckAvailability(ctx context.Context, in *Ch
 	return out, nil
 }
 
+func (c *reservationServiceClient) BatchCheckAvailability(ctx context.Context, in *BatchCheckAvailabilityRequest, opts ...grpc.CallOption) (*BatchCheckAvailabilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchCheckAvailabilityResponse)
+	err := c.cc.Invoke(ctx, ReservationService_BatchCheckAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) FindFreeCubicles(ctx context.Context, in *FindFreeCubiclesRequest, opts ...grpc.CallOption) (*FindFreeCubiclesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindFreeCubiclesResponse)
+	err := c.cc.Invoke(ctx, ReservationService_FindFreeCubicles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *reservationServiceClient) CreateReservation(ctx context.Context, in *CreateReservationRequest, opts ...grpc.CallOption) (*CreateReservationResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateReservationResponse)
@@ -203,8 +670,118 @@ func (c *reservationServiceClient) CreateReservation(ctx context.Context, in *Cr
 
 func (c *reservationServiceClient) CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*CancelReservationResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(CancelReservationResponse)
-	err := c.cc.Invoke(ctx, ReservationService_CancelReservation_FullMethodName, in, out, cOpts...)
+	out := new(CancelReservationResponse)
+	err := c.cc.Invoke(ctx, ReservationService_CancelReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) CancelSeries(ctx context.Context, in *CancelSeriesRequest, opts ...grpc.CallOption) (*CancelSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelSeriesResponse)
+	err := c.cc.Invoke(ctx, ReservationService_CancelSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) ConfirmReservation(ctx context.Context, in *ConfirmReservationRequest, opts ...grpc.CallOption) (*ConfirmReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmReservationResponse)
+	err := c.cc.Invoke(ctx, ReservationService_ConfirmReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*CheckInResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckInResponse)
+	err := c.cc.Invoke(ctx, ReservationService_CheckIn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) CompleteReservation(ctx context.Context, in *CompleteReservationRequest, opts ...grpc.CallOption) (*CompleteReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteReservationResponse)
+	err := c.cc.Invoke(ctx, ReservationService_CompleteReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) MarkNoShow(ctx context.Context, in *MarkNoShowRequest, opts ...grpc.CallOption) (*MarkNoShowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkNoShowResponse)
+	err := c.cc.Invoke(ctx, ReservationService_MarkNoShow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) ListReservations(ctx context.Context, in *ListReservationsRequest, opts ...grpc.CallOption) (*ListReservationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReservationsResponse)
+	err := c.cc.Invoke(ctx, ReservationService_ListReservations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) ListFreeIntervals(ctx context.Context, in *ListFreeIntervalsRequest, opts ...grpc.CallOption) (*ListFreeIntervalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFreeIntervalsResponse)
+	err := c.cc.Invoke(ctx, ReservationService_ListFreeIntervals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) JoinWaitlist(ctx context.Context, in *JoinWaitlistRequest, opts ...grpc.CallOption) (*JoinWaitlistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JoinWaitlistResponse)
+	err := c.cc.Invoke(ctx, ReservationService_JoinWaitlist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) LeaveWaitlist(ctx context.Context, in *LeaveWaitlistRequest, opts ...grpc.CallOption) (*LeaveWaitlistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveWaitlistResponse)
+	err := c.cc.Invoke(ctx, ReservationService_LeaveWaitlist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) AcceptWaitlistOffer(ctx context.Context, in *AcceptWaitlistOfferRequest, opts ...grpc.CallOption) (*AcceptWaitlistOfferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceptWaitlistOfferResponse)
+	err := c.cc.Invoke(ctx, ReservationService_AcceptWaitlistOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reservationServiceClient) ListWaitlist(ctx context.Context, in *ListWaitlistRequest, opts ...grpc.CallOption) (*ListWaitlistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWaitlistResponse)
+	err := c.cc.Invoke(ctx, ReservationService_ListWaitlist_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -216,8 +793,22 @@ func (c *reservationServiceClient) CancelReservation(ctx context.Context, in *Ca
 // for forward compatibility.
 type ReservationServiceServer interface {
 	CheckAvailability(context.Context, *CheckAvailabilityRequest) (*CheckAvailabilityResponse, error)
+	BatchCheckAvailability(context.Context, *BatchCheckAvailabilityRequest) (*BatchCheckAvailabilityResponse, error)
+	FindFreeCubicles(context.Context, *FindFreeCubiclesRequest) (*FindFreeCubiclesResponse, error)
 	CreateReservation(context.Context, *CreateReservationRequest) (*CreateReservationResponse, error)
 	CancelReservation(context.Context, *CancelReservationRequest) (*CancelReservationResponse, error)
+	CancelSeries(context.Context, *CancelSeriesRequest) (*CancelSeriesResponse, error)
+	ConfirmReservation(context.Context, *ConfirmReservationRequest) (*ConfirmReservationResponse, error)
+	CheckIn(context.Context, *CheckInRequest) (*CheckInResponse, error)
+	CompleteReservation(context.Context, *CompleteReservationRequest) (*CompleteReservationResponse, error)
+	MarkNoShow(context.Context, *MarkNoShowRequest) (*MarkNoShowResponse, error)
+	ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error)
+	ListFreeIntervals(context.Context, *ListFreeIntervalsRequest) (*ListFreeIntervalsResponse, error)
+	// Lista de espera para intervalos ocupados
+	JoinWaitlist(context.Context, *JoinWaitlistRequest) (*JoinWaitlistResponse, error)
+	LeaveWaitlist(context.Context, *LeaveWaitlistRequest) (*LeaveWaitlistResponse, error)
+	AcceptWaitlistOffer(context.Context, *AcceptWaitlistOfferRequest) (*AcceptWaitlistOfferResponse, error)
+	ListWaitlist(context.Context, *ListWaitlistRequest) (*ListWaitlistResponse, error)
 	mustEmbedUnimplementedReservationServiceServer()
 }
 
@@ -231,12 +822,51 @@ type UnimplementedReservationServiceServer struct{}
 func (UnimplementedReservationServiceServer) CheckAvailability(context.Context, *CheckAvailabilityRequest) (*CheckAvailabilityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckAvailability not implemented")
 }
+func (UnimplementedReservationServiceServer) BatchCheckAvailability(context.Context, *BatchCheckAvailabilityRequest) (*BatchCheckAvailabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCheckAvailability not implemented")
+}
+func (UnimplementedReservationServiceServer) FindFreeCubicles(context.Context, *FindFreeCubiclesRequest) (*FindFreeCubiclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindFreeCubicles not implemented")
+}
 func (UnimplementedReservationServiceServer) CreateReservation(context.Context, *CreateReservationRequest) (*CreateReservationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateReservation not implemented")
 }
 func (UnimplementedReservationServiceServer) CancelReservation(context.Context, *CancelReservationRequest) (*CancelReservationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelReservation not implemented")
 }
+func (UnimplementedReservationServiceServer) CancelSeries(context.Context, *CancelSeriesRequest) (*CancelSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSeries not implemented")
+}
+func (UnimplementedReservationServiceServer) ConfirmReservation(context.Context, *ConfirmReservationRequest) (*ConfirmReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmReservation not implemented")
+}
+func (UnimplementedReservationServiceServer) CheckIn(context.Context, *CheckInRequest) (*CheckInResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckIn not implemented")
+}
+func (UnimplementedReservationServiceServer) CompleteReservation(context.Context, *CompleteReservationRequest) (*CompleteReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteReservation not implemented")
+}
+func (UnimplementedReservationServiceServer) MarkNoShow(context.Context, *MarkNoShowRequest) (*MarkNoShowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkNoShow not implemented")
+}
+func (UnimplementedReservationServiceServer) ListReservations(context.Context, *ListReservationsRequest) (*ListReservationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReservations not implemented")
+}
+func (UnimplementedReservationServiceServer) ListFreeIntervals(context.Context, *ListFreeIntervalsRequest) (*ListFreeIntervalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFreeIntervals not implemented")
+}
+func (UnimplementedReservationServiceServer) JoinWaitlist(context.Context, *JoinWaitlistRequest) (*JoinWaitlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinWaitlist not implemented")
+}
+func (UnimplementedReservationServiceServer) LeaveWaitlist(context.Context, *LeaveWaitlistRequest) (*LeaveWaitlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveWaitlist not implemented")
+}
+func (UnimplementedReservationServiceServer) AcceptWaitlistOffer(context.Context, *AcceptWaitlistOfferRequest) (*AcceptWaitlistOfferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptWaitlistOffer not implemented")
+}
+func (UnimplementedReservationServiceServer) ListWaitlist(context.Context, *ListWaitlistRequest) (*ListWaitlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWaitlist not implemented")
+}
 func (UnimplementedReservationServiceServer) mustEmbedUnimplementedReservationServiceServer() {}
 func (UnimplementedReservationServiceServer) testEmbeddedByValue()                            {}
 
@@ -276,6 +906,42 @@ func _ReservationService_CheckAvailability_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReservationService_BatchCheckAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCheckAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).BatchCheckAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_BatchCheckAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).BatchCheckAvailability(ctx, req.(*BatchCheckAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_FindFreeCubicles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindFreeCubiclesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).FindFreeCubicles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_FindFreeCubicles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).FindFreeCubicles(ctx, req.(*FindFreeCubiclesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ReservationService_CreateReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateReservationRequest)
 	if err := dec(in); err != nil {
@@ -312,6 +978,204 @@ func _ReservationService_CancelReservation_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReservationService_CancelSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).CancelSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_CancelSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).CancelSeries(ctx, req.(*CancelSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_ConfirmReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).ConfirmReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_ConfirmReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).ConfirmReservation(ctx, req.(*ConfirmReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_CheckIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).CheckIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_CheckIn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).CheckIn(ctx, req.(*CheckInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_CompleteReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).CompleteReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_CompleteReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).CompleteReservation(ctx, req.(*CompleteReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_MarkNoShow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkNoShowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).MarkNoShow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_MarkNoShow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).MarkNoShow(ctx, req.(*MarkNoShowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_ListReservations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReservationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).ListReservations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_ListReservations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).ListReservations(ctx, req.(*ListReservationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_ListFreeIntervals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFreeIntervalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).ListFreeIntervals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_ListFreeIntervals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).ListFreeIntervals(ctx, req.(*ListFreeIntervalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_JoinWaitlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinWaitlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).JoinWaitlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_JoinWaitlist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).JoinWaitlist(ctx, req.(*JoinWaitlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_LeaveWaitlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveWaitlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).LeaveWaitlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_LeaveWaitlist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).LeaveWaitlist(ctx, req.(*LeaveWaitlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_AcceptWaitlistOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptWaitlistOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).AcceptWaitlistOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_AcceptWaitlistOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).AcceptWaitlistOffer(ctx, req.(*AcceptWaitlistOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReservationService_ListWaitlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWaitlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReservationServiceServer).ListWaitlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReservationService_ListWaitlist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReservationServiceServer).ListWaitlist(ctx, req.(*ListWaitlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ReservationService_ServiceDesc is the grpc.ServiceDesc for ReservationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -323,6 +1187,14 @@ var ReservationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckAvailability",
 			Handler:    _ReservationService_CheckAvailability_Handler,
 		},
+		{
+			MethodName: "BatchCheckAvailability",
+			Handler:    _ReservationService_BatchCheckAvailability_Handler,
+		},
+		{
+			MethodName: "FindFreeCubicles",
+			Handler:    _ReservationService_FindFreeCubicles_Handler,
+		},
 		{
 			MethodName: "CreateReservation",
 			Handler:    _ReservationService_CreateReservation_Handler,
@@ -331,13 +1203,59 @@ var ReservationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelReservation",
 			Handler:    _ReservationService_CancelReservation_Handler,
 		},
+		{
+			MethodName: "CancelSeries",
+			Handler:    _ReservationService_CancelSeries_Handler,
+		},
+		{
+			MethodName: "ConfirmReservation",
+			Handler:    _ReservationService_ConfirmReservation_Handler,
+		},
+		{
+			MethodName: "CheckIn",
+			Handler:    _ReservationService_CheckIn_Handler,
+		},
+		{
+			MethodName: "CompleteReservation",
+			Handler:    _ReservationService_CompleteReservation_Handler,
+		},
+		{
+			MethodName: "MarkNoShow",
+			Handler:    _ReservationService_MarkNoShow_Handler,
+		},
+		{
+			MethodName: "ListReservations",
+			Handler:    _ReservationService_ListReservations_Handler,
+		},
+		{
+			MethodName: "ListFreeIntervals",
+			Handler:    _ReservationService_ListFreeIntervals_Handler,
+		},
+		{
+			MethodName: "JoinWaitlist",
+			Handler:    _ReservationService_JoinWaitlist_Handler,
+		},
+		{
+			MethodName: "LeaveWaitlist",
+			Handler:    _ReservationService_LeaveWaitlist_Handler,
+		},
+		{
+			MethodName: "AcceptWaitlistOffer",
+			Handler:    _ReservationService_AcceptWaitlistOffer_Handler,
+		},
+		{
+			MethodName: "ListWaitlist",
+			Handler:    _ReservationService_ListWaitlist_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cubicles.proto",
 }
 
 const (
-	CubicleService_GetCubicle_FullMethodName = "/cubicles.CubicleService/GetCubicle"
+	CubicleService_GetCubicle_FullMethodName       = "/cubicles.CubicleService/GetCubicle"
+	CubicleService_SearchCubicles_FullMethodName   = "/cubicles.CubicleService/SearchCubicles"
+	CubicleService_BatchGetCubicles_FullMethodName = "/cubicles.CubicleService/BatchGetCubicles"
 )
 
 // CubicleServiceClient is the client API for CubicleService service.
@@ -346,6 +1264,10 @@ const (
 type CubicleServiceClient interface {
 	// Agrega datos de metadata + disponibilidad
 	GetCubicle(ctx context.Context, in *GetCubicleRequest, opts ...grpc.CallOption) (*GetCubicleResponse, error)
+	// Busca cubículos libres combinando filtros de metadata con disponibilidad
+	SearchCubicles(ctx context.Context, in *SearchCubiclesRequest, opts ...grpc.CallOption) (*SearchCubiclesResponse, error)
+	// Igual que GetCubicle para varios ids, con llamadas concurrentes a los servicios internos
+	BatchGetCubicles(ctx context.Context, in *BatchGetCubiclesRequest, opts ...grpc.CallOption) (*BatchGetCubiclesResponse, error)
 }
 
 type cubicleServiceClient struct {
@@ -366,12 +1288,36 @@ func (c *cubicleServiceClient) GetCubicle(ctx context.Context, in *GetCubicleReq
 	return out, nil
 }
 
+func (c *cubicleServiceClient) SearchCubicles(ctx context.Context, in *SearchCubiclesRequest, opts ...grpc.CallOption) (*SearchCubiclesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchCubiclesResponse)
+	err := c.cc.Invoke(ctx, CubicleService_SearchCubicles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cubicleServiceClient) BatchGetCubicles(ctx context.Context, in *BatchGetCubiclesRequest, opts ...grpc.CallOption) (*BatchGetCubiclesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetCubiclesResponse)
+	err := c.cc.Invoke(ctx, CubicleService_BatchGetCubicles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CubicleServiceServer is the server API for CubicleService service.
 // All implementations must embed UnimplementedCubicleServiceServer
 // for forward compatibility.
 type CubicleServiceServer interface {
 	// Agrega datos de metadata + disponibilidad
 	GetCubicle(context.Context, *GetCubicleRequest) (*GetCubicleResponse, error)
+	// Busca cubículos libres combinando filtros de metadata con disponibilidad
+	SearchCubicles(context.Context, *SearchCubiclesRequest) (*SearchCubiclesResponse, error)
+	// Igual que GetCubicle para varios ids, con llamadas concurrentes a los servicios internos
+	BatchGetCubicles(context.Context, *BatchGetCubiclesRequest) (*BatchGetCubiclesResponse, error)
 	mustEmbedUnimplementedCubicleServiceServer()
 }
 
@@ -385,6 +1331,12 @@ type UnimplementedCubicleServiceServer struct{}
 func (UnimplementedCubicleServiceServer) GetCubicle(context.Context, *GetCubicleRequest) (*GetCubicleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCubicle not implemented")
 }
+func (UnimplementedCubicleServiceServer) SearchCubicles(context.Context, *SearchCubiclesRequest) (*SearchCubiclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchCubicles not implemented")
+}
+func (UnimplementedCubicleServiceServer) BatchGetCubicles(context.Context, *BatchGetCubiclesRequest) (*BatchGetCubiclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetCubicles not implemented")
+}
 func (UnimplementedCubicleServiceServer) mustEmbedUnimplementedCubicleServiceServer() {}
 func (UnimplementedCubicleServiceServer) testEmbeddedByValue()                        {}
 
@@ -424,6 +1376,42 @@ func _CubicleService_GetCubicle_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CubicleService_SearchCubicles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchCubiclesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CubicleServiceServer).SearchCubicles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CubicleService_SearchCubicles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CubicleServiceServer).SearchCubicles(ctx, req.(*SearchCubiclesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CubicleService_BatchGetCubicles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetCubiclesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CubicleServiceServer).BatchGetCubicles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CubicleService_BatchGetCubicles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CubicleServiceServer).BatchGetCubicles(ctx, req.(*BatchGetCubiclesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CubicleService_ServiceDesc is the grpc.ServiceDesc for CubicleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -435,6 +1423,14 @@ var CubicleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCubicle",
 			Handler:    _CubicleService_GetCubicle_Handler,
 		},
+		{
+			MethodName: "SearchCubicles",
+			Handler:    _CubicleService_SearchCubicles_Handler,
+		},
+		{
+			MethodName: "BatchGetCubicles",
+			Handler:    _CubicleService_BatchGetCubicles_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cubicles.proto",
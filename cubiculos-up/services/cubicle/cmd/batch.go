@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"cubiculosup.com/internal/grpcerr"
+	pb "cubiculosup.com/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxBatchGetCubicles limita los ids por llamada de BatchGetCubicles.
+	maxBatchGetCubicles = 500
+	// batchChunkSize es el número de ids por llamada a los servicios internos.
+	batchChunkSize = 25
+	// maxParallelCalls acota las llamadas simultáneas a los servicios internos.
+	maxParallelCalls = 8
+)
+
+// chunk parte ids en grupos de a lo más size.
+func chunk(ids []string, size int) [][]string {
+	var out [][]string
+	for len(ids) > size {
+		out = append(out, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		out = append(out, ids)
+	}
+	return out
+}
+
+// itemError convierte el error de una llamada interna en el ItemError de un id.
+func itemError(id string, err error) *pb.ItemError {
+	st := status.Convert(err)
+	return &pb.ItemError{Id: id, Code: int32(st.Code()), Message: st.Message()}
+}
+
+// BatchGetCubicles obtiene metadata y disponibilidad de varios cubículos. Los ids se parten
+// en grupos y cada grupo se pide a MetadataService y ReservationService en paralelo, con a
+// lo más maxParallelCalls llamadas en vuelo. Un fallo afecta solo a los ids de su grupo.
+func (s *cubicleServer) BatchGetCubicles(ctx context.Context, req *pb.BatchGetCubiclesRequest) (*pb.BatchGetCubiclesResponse, error) {
+	if len(req.CubicleIds) > maxBatchGetCubicles {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d cubicleIds per call", maxBatchGetCubicles)
+	}
+
+	// Ids únicos en el orden pedido.
+	var ids []string
+	seen := map[string]bool{}
+	for _, id := range req.CubicleIds {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		meta     = map[string]*pb.Metadata{}
+		avail    = map[string]*pb.Availability{}
+		errs     = map[string]*pb.ItemError{}
+		degraded = map[string]string{} // por qué la disponibilidad de un id es desconocida
+		wg       sync.WaitGroup
+		tokens   = make(chan struct{}, maxParallelCalls)
+	)
+
+	// fail registra err para los ids que todavía no tienen error.
+	fail := func(ids []string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, id := range ids {
+			if _, ok := errs[id]; !ok {
+				errs[id] = itemError(id, err)
+			}
+		}
+	}
+
+	run := func(fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tokens <- struct{}{}
+			defer func() { <-tokens }()
+			fn()
+		}()
+	}
+
+	for _, group := range chunk(ids, batchChunkSize) {
+		run(func() {
+			metaCtx, cancel := backendContext(ctx, s.metaTimeout)
+			defer cancel()
+			resp, err := s.metaClient.BatchGetMetadata(metaCtx, &pb.BatchGetMetadataRequest{CubicleIds: group})
+			if err != nil {
+				fail(group, grpcerr.Upstream(err, "metadata"))
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, m := range resp.Metadata {
+				meta[m.Id] = m
+			}
+			for _, e := range resp.Errors {
+				errs[e.Id] = e
+			}
+		})
+
+		run(func() {
+			resCtx, cancel := backendContext(ctx, s.resTimeout)
+			defer cancel()
+			resp, err := s.resClient.BatchCheckAvailability(resCtx, &pb.BatchCheckAvailabilityRequest{CubicleIds: group})
+			if err != nil {
+				// Igual que GetCubicle: sin Reservation se entrega la metadata sin disponibilidad.
+				log.Printf("WARNING: availability of %d cubicles unknown: %v", len(group), err)
+				availability, reason := unknownAvailability(err)
+				mu.Lock()
+				defer mu.Unlock()
+				for _, id := range group {
+					avail[id] = availability
+					degraded[id] = reason
+				}
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, a := range resp.Availability {
+				avail[a.CubicleId] = a.Availability
+			}
+			// MetadataService reporta los mismos ids inexistentes; se conserva el primero.
+			for _, e := range resp.Errors {
+				if _, ok := errs[e.Id]; !ok {
+					errs[e.Id] = e
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	resp := &pb.BatchGetCubiclesResponse{}
+	for _, id := range ids {
+		result := &pb.CubicleResult{CubicleId: id}
+		switch {
+		case errs[id] != nil:
+			result.Error = errs[id]
+		case meta[id] == nil || avail[id] == nil:
+			result.Error = &pb.ItemError{Id: id, Code: int32(codes.Internal), Message: "incomplete response from internal services"}
+		default:
+			result.Details = &pb.CubicleDetails{Metadata: meta[id], Reservation: avail[id], DegradedReason: degraded[id]}
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
@@ -5,15 +5,24 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"os"
 	"time"
 
+	"cubiculosup.com/internal/auth"
+	"cubiculosup.com/internal/config"
+	"cubiculosup.com/internal/graceful"
+	"cubiculosup.com/internal/grpcerr"
+	"cubiculosup.com/internal/healthcheck"
+	"cubiculosup.com/internal/tlsconfig"
 	pb "cubiculosup.com/proto"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer/roundrobin"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
 )
 
 func init() {
@@ -29,76 +38,123 @@ func newDialer() func(context.Context, string) (net.Conn, error) {
 
 type cubicleServer struct {
 	pb.UnimplementedCubicleServiceServer
-	metaClient pb.MetadataServiceClient
-	resClient  pb.ReservationServiceClient
+	metaClient  pb.MetadataServiceClient
+	resClient   pb.ReservationServiceClient
+	metaConn    *grpc.ClientConn
+	resConn     *grpc.ClientConn
+	metaTimeout time.Duration // plazo de cada llamada a Metadata
+	resTimeout  time.Duration // plazo de cada llamada a Reservation
 }
 
 // NewCubicleServer inicializa los clientes gRPC para los servicios de Metadata y Reservation.
-func NewCubicleServer() *cubicleServer {
-	// Definimos un contexto con timeout para la conexión inicial
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	// Usamos el prefijo "dns:///" para forzar al cliente gRPC a usar el resolvedor DNS de Go.
+// Las llamadas internas llevan el token del usuario, o el de servicio de authCfg si no hay,
+// y viajan con las credenciales creds. Las conexiones se abren en la primera llamada (o en
+// el primer health check), igual que en ReservationService.
+func NewCubicleServer(meta, res *config.Peer, authCfg auth.Config, creds credentials.TransportCredentials) (*cubicleServer, error) {
+	// Target usa el prefijo "dns:///" para forzar al cliente gRPC a usar el resolvedor DNS de Go.
 	// Esto permite el balanceo de carga entre los pods del servicio interno.
-	metaAddr := "dns:///metadata:50051"
-	resAddr := "dns:///reservation:50052"
-
 	serviceConfig := fmt.Sprintf(`{"loadBalancingPolicy":"%s"}`, roundrobin.Name)
 
 	// Creamos el dialer personalizado que fuerza IPv4
 	dialer := newDialer()
+	authInterceptor := auth.UnaryClientInterceptor(auth.NewServiceToken(authCfg, "cubicle"))
 
 	// --- Conexión a Metadata ---
-	metaConn, err := grpc.DialContext(
-		ctx,
-		metaAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	metaConn, err := grpc.NewClient(
+		meta.Target(),
+		grpc.WithTransportCredentials(creds),
 		grpc.WithDefaultServiceConfig(serviceConfig),
 		grpc.WithContextDialer(dialer),
+		grpc.WithChainUnaryInterceptor(authInterceptor),
 	)
-
 	if err != nil {
-		log.Printf("WARNING: Could not connect immediately to metadata service: %v", err)
+		return nil, fmt.Errorf("metadata client: %w", err)
 	}
 
 	// --- Conexión a Reservation (Puerto 50052) ---
-	resConn, err := grpc.DialContext(
-		ctx,
-		resAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	resConn, err := grpc.NewClient(
+		res.Target(),
+		grpc.WithTransportCredentials(creds),
 		grpc.WithDefaultServiceConfig(serviceConfig),
 		grpc.WithContextDialer(dialer),
+		grpc.WithChainUnaryInterceptor(authInterceptor),
 	)
 	if err != nil {
-		log.Printf("WARNING: Could not connect immediately to reservation service: %v", err)
+		graceful.Close("metadata connection", metaConn)
+		return nil, fmt.Errorf("reservation client: %w", err)
 	}
 
 	return &cubicleServer{
-		metaClient: pb.NewMetadataServiceClient(metaConn),
-		resClient:  pb.NewReservationServiceClient(resConn),
+		metaClient:  pb.NewMetadataServiceClient(metaConn),
+		resClient:   pb.NewReservationServiceClient(resConn),
+		metaConn:    metaConn,
+		resConn:     resConn,
+		metaTimeout: meta.Timeout,
+		resTimeout:  res.Timeout,
+	}, nil
+}
+
+// Close cierra las conexiones con Metadata y Reservation.
+func (s *cubicleServer) Close() {
+	graceful.Close("metadata connection", s.metaConn)
+	graceful.Close("reservation connection", s.resConn)
+}
+
+// defaultBackendTimeout es el plazo de cada llamada interna si no se configura
+// METADATA_TIMEOUT o RESERVATION_TIMEOUT.
+const defaultBackendTimeout = 2 * time.Second
+
+// backendContext deriva el contexto de una llamada interna: usa el deadline del cliente
+// menos un margen para poder responder a tiempo, sin pasar de timeout.
+func backendContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if deadline, ok := ctx.Deadline(); ok {
+		// Se reserva el 10% del tiempo restante para armar la respuesta.
+		if remaining := time.Until(deadline) * 9 / 10; remaining < timeout {
+			timeout = remaining
+		}
 	}
+	return context.WithTimeout(ctx, timeout)
 }
 
-// GetCubicle implementa el método del servicio CubicleService.
+// unknownAvailability se usa cuando ReservationService falla: la respuesta conserva la
+// metadata y marca la disponibilidad como desconocida.
+func unknownAvailability(err error) (*pb.Availability, string) {
+	return &pb.Availability{Unknown: true}, status.Convert(grpcerr.Upstream(err, "reservation")).Message()
+}
+
+// GetCubicle implementa el método del servicio CubicleService. Consulta Metadata y
+// Reservation en paralelo; si falla Reservation regresa la metadata con disponibilidad
+// desconocida en lugar de fallar toda la petición.
 func (s *cubicleServer) GetCubicle(ctx context.Context, req *pb.GetCubicleRequest) (*pb.GetCubicleResponse, error) {
 	id := req.CubicleId
 
-	// Llama al servicio Metadata (conexión interna)
-	meta, err := s.metaClient.GetMetadata(ctx, &pb.GetMetadataRequest{CubicleId: id})
+	// Llama al servicio Reservation (conexión interna) en segundo plano
+	availCh := make(chan *pb.CheckAvailabilityResponse, 1)
+	availErrCh := make(chan error, 1)
+	go func() {
+		resCtx, cancel := backendContext(ctx, s.resTimeout)
+		defer cancel()
+		avail, err := s.resClient.CheckAvailability(resCtx, &pb.CheckAvailabilityRequest{CubicleId: id})
+		availCh <- avail
+		availErrCh <- err
+	}()
+
+	// Llama al servicio Metadata (conexión interna); sin metadata no hay respuesta útil.
+	metaCtx, cancel := backendContext(ctx, s.metaTimeout)
+	defer cancel()
+	meta, err := s.metaClient.GetMetadata(metaCtx, &pb.GetMetadataRequest{CubicleId: id})
 	if err != nil {
-		return nil, fmt.Errorf("error calling metadata service: %v", err)
+		return nil, grpcerr.Upstream(err, "metadata")
 	}
 
-	// Llama al servicio Reservation (conexión interna)
-	avail, err := s.resClient.CheckAvailability(ctx, &pb.CheckAvailabilityRequest{CubicleId: id})
-	if err != nil {
-		return nil, fmt.Errorf("error calling reservation service: %v", err)
-	}
+	details := &pb.CubicleDetails{Metadata: meta.Metadata}
 
-	details := &pb.CubicleDetails{
-		Metadata:    meta.Metadata,
-		Reservation: avail.Availability,
+	avail, err := <-availCh, <-availErrCh
+	if err != nil {
+		log.Printf("WARNING: availability of cubicle %s unknown: %v", id, err)
+		details.Reservation, details.DegradedReason = unknownAvailability(err)
+	} else {
+		details.Reservation = avail.Availability
 	}
 
 	return &pb.GetCubicleResponse{
@@ -107,20 +163,83 @@ func (s *cubicleServer) GetCubicle(ctx context.Context, req *pb.GetCubicleReques
 }
 
 func main() {
-	// Escucha en todas las interfaces en el puerto 50053
-	lis, err := net.Listen("tcp", ":50053")
+	loader := config.NewLoader("cubicle")
+	listen := loader.Listen(50053)
+	metaPeer := loader.Peer("metadata", "metadata:50051", defaultBackendTimeout)
+	resPeer := loader.Peer("reservation", "reservation:50052", defaultBackendTimeout)
+	healthCfg := loader.Health()
+	shutdownCfg := loader.Shutdown()
+	if err := loader.Load(os.Args[1:]); err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+	// ctx termina con SIGTERM: detiene los trabajos en segundo plano y drena el servidor.
+	ctx := graceful.SignalContext()
+
+	// Escucha en todas las interfaces
+	lis, err := net.Listen("tcp", listen.Addr())
 	if err != nil {
 		log.Fatalf("error listening: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterCubicleServiceServer(grpcServer, NewCubicleServer())
+	authCfg := auth.ConfigFromEnv()
+	verifier, err := auth.NewVerifier(authCfg)
+	if err != nil {
+		log.Fatalf("cannot configure authentication: %v", err)
+	}
+	if verifier.Disabled() {
+		log.Println("WARNING: authentication is disabled (AUTH_DISABLED=true)")
+	}
+
+	tlsCfg := tlsconfig.FromEnv()
+	serverCreds, err := tlsconfig.ServerCredentials(tlsCfg)
+	if err != nil {
+		log.Fatalf("cannot configure TLS: %v", err)
+	}
+	clientCreds, err := tlsconfig.ClientCredentials(tlsCfg)
+	if err != nil {
+		log.Fatalf("cannot configure TLS: %v", err)
+	}
+	if tlsCfg.Insecure {
+		log.Println("WARNING: serving without TLS (TLS_INSECURE=true)")
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.Creds(serverCreds),
+		grpc.ChainUnaryInterceptor(verifier.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(verifier.StreamServerInterceptor()),
+	)
+	server, err := NewCubicleServer(metaPeer, resPeer, authCfg, clientCreds)
+	if err != nil {
+		log.Fatalf("cannot create backend clients: %v", err)
+	}
+	pb.RegisterCubicleServiceServer(grpcServer, server)
 
 	reflection.Register(grpcServer)
 
-	log.Println("Cubicle service running on port 50053")
+	// Readiness: sin Metadata no hay respuesta útil, así que se deja de servir si esa
+	// conexión falla. Sin Reservation las respuestas salen con disponibilidad desconocida,
+	// así que no cuenta.
+	hs := healthcheck.NewServer(pb.CubicleService_ServiceDesc.ServiceName)
+	healthpb.RegisterHealthServer(grpcServer, hs)
+	checks := []healthcheck.Check{healthcheck.Conn("metadata", server.metaConn)}
+	go healthcheck.Watch(ctx, hs, healthCfg.Interval, checks, pb.CubicleService_ServiceDesc.ServiceName)
+	var probes *grpc.Server
+	if healthCfg.Port != 0 {
+		if probes, err = healthcheck.Serve(healthCfg.Addr(), hs); err != nil {
+			log.Fatalf("cannot serve health checks: %v", err)
+		}
+	}
+
+	log.Printf("Cubicle service running on port %d", listen.Port)
 
-	if err := grpcServer.Serve(lis); err != nil {
+	if err := graceful.Serve(ctx, grpcServer, lis, hs, shutdownCfg.Delay, shutdownCfg.Timeout); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
+
+	// Las llamadas a los servicios internos terminaron junto con las de los clientes.
+	if probes != nil {
+		probes.Stop()
+	}
+	server.Close()
+	log.Println("Cubicle service stopped")
 }
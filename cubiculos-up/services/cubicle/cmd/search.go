@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cubiculosup.com/internal/grpcerr"
+	pb "cubiculosup.com/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultSearchResults = 10
+	maxSearchResults     = 50
+
+	// searchPageSize y maxSearchPages acotan cuántos cubículos se revisan por búsqueda.
+	searchPageSize = 200
+	maxSearchPages = 10
+
+	// lookupBatchSize es el máximo de ids por llamada a FindFreeCubicles y GetCalendars.
+	lookupBatchSize = 500
+
+	// maxSearchWindow es el rango máximo que acepta GetCalendars en MetadataService; se
+	// revisa aquí para no consultar metadata y reservas antes de que lo rechace.
+	maxSearchWindow = 31 * 24 * time.Hour
+)
+
+// SearchCubicles filtra cubículos en MetadataService, descarta los que tienen reservas en
+// la ventana pedida o no están abiertos durante toda ella y ordena el resto por asientos
+// sobrantes y nombre.
+func (s *cubicleServer) SearchCubicles(ctx context.Context, req *pb.SearchCubiclesRequest) (*pb.SearchCubiclesResponse, error) {
+	if req.Start == nil || req.Start.CheckValid() != nil {
+		return nil, invalidField("start", "start is required")
+	}
+	if req.End == nil || req.End.CheckValid() != nil {
+		return nil, invalidField("end", "end is required")
+	}
+	if d := req.End.AsTime().Sub(req.Start.AsTime()); d <= 0 {
+		return nil, invalidField("end", "end must be after start")
+	} else if d > maxSearchWindow {
+		return nil, invalidField("end", fmt.Sprintf("search window lasts %s, maximum is %s", d, maxSearchWindow))
+	}
+	if req.MinCapacity < 0 {
+		return nil, invalidField("minCapacity", "minCapacity must not be negative")
+	}
+
+	maxResults := int(req.MaxResults)
+	if maxResults <= 0 {
+		maxResults = defaultSearchResults
+	} else if maxResults > maxSearchResults {
+		maxResults = maxSearchResults
+	}
+
+	// 1. Cubículos que cumplen los filtros de metadata.
+	var cubicles []*pb.Metadata
+	pageToken := ""
+	for page := 0; page < maxSearchPages; page++ {
+		resp, err := s.metaClient.ListMetadata(ctx, &pb.ListMetadataRequest{
+			Location:    req.Location,
+			MinCapacity: req.MinCapacity,
+			OrderBy:     "capacity",
+			PageSize:    searchPageSize,
+			PageToken:   pageToken,
+		})
+		if err != nil {
+			return nil, grpcerr.Upstream(err, "metadata")
+		}
+		cubicles = append(cubicles, resp.Metadata...)
+		pageToken = resp.NextPageToken
+		if pageToken == "" {
+			break
+		}
+	}
+	if len(cubicles) == 0 {
+		return &pb.SearchCubiclesResponse{}, nil
+	}
+
+	// 2. Cubículos sin reservas en la ventana, según ReservationService.
+	ids := make([]string, len(cubicles))
+	for i, m := range cubicles {
+		ids[i] = m.Id
+	}
+	free, err := s.freeDuring(ctx, ids, req.Start, req.End)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Cubículos abiertos durante toda la ventana según su calendario.
+	open, err := s.openDuring(ctx, free, req.Start, req.End)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Candidatos libres, primero los que mejor se ajustan al tamaño del grupo.
+	var candidates []*pb.CubicleCandidate
+	for _, m := range cubicles {
+		if !open[m.Id] {
+			continue
+		}
+		candidates = append(candidates, &pb.CubicleCandidate{
+			Metadata:   m,
+			SpareSeats: m.Capacity - req.MinCapacity,
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].SpareSeats != candidates[j].SpareSeats {
+			return candidates[i].SpareSeats < candidates[j].SpareSeats
+		}
+		return candidates[i].Metadata.Name < candidates[j].Metadata.Name
+	})
+	if len(candidates) > maxResults {
+		candidates = candidates[:maxResults]
+	}
+
+	return &pb.SearchCubiclesResponse{Candidates: candidates}, nil
+}
+
+// freeDuring regresa, en orden, los ids que ninguna reserva ocupa en [start, end). Qué
+// estados ocupan un cubículo lo decide ReservationService.
+func (s *cubicleServer) freeDuring(ctx context.Context, ids []string, start, end *timestamppb.Timestamp) ([]string, error) {
+	var free []string
+	for _, group := range chunk(ids, lookupBatchSize) {
+		resp, err := s.resClient.FindFreeCubicles(ctx, &pb.FindFreeCubiclesRequest{CubicleIds: group, From: start, To: end})
+		if err != nil {
+			return nil, grpcerr.Upstream(err, "reservation")
+		}
+		free = append(free, resp.CubicleIds...)
+	}
+	return free, nil
+}
+
+// openDuring regresa cuáles de ids están abiertos durante todo [start, end). Como
+// GetCalendars une los intervalos contiguos, basta con que el primero cubra la ventana.
+func (s *cubicleServer) openDuring(ctx context.Context, ids []string, start, end *timestamppb.Timestamp) (map[string]bool, error) {
+	open := map[string]bool{}
+	for _, group := range chunk(ids, lookupBatchSize) {
+		resp, err := s.metaClient.GetCalendars(ctx, &pb.GetCalendarsRequest{CubicleIds: group, From: start, To: end})
+		if err != nil {
+			return nil, grpcerr.Upstream(err, "metadata")
+		}
+		for _, cal := range resp.Calendars {
+			if len(cal.Open) == 1 && cal.Open[0].Start.AsTime().Equal(start.AsTime()) && cal.Open[0].End.AsTime().Equal(end.AsTime()) {
+				open[cal.CubicleId] = true
+			}
+		}
+	}
+	return open, nil
+}
+
+// invalidField regresa un InvalidArgument con la violación de field en errdetails.BadRequest.
+func invalidField(field, description string) error {
+	st := status.New(codes.InvalidArgument, description)
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}},
+	}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
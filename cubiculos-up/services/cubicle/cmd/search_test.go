@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"cubiculosup.com/internal/auth"
+	pb "cubiculosup.com/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeMetadata regresa cubicles y los reporta abiertos durante toda la ventana pedida.
+type fakeMetadata struct {
+	pb.MetadataServiceClient
+	cubicles []*pb.Metadata
+}
+
+func (f fakeMetadata) ListMetadata(context.Context, *pb.ListMetadataRequest, ...grpc.CallOption) (*pb.ListMetadataResponse, error) {
+	return &pb.ListMetadataResponse{Metadata: f.cubicles}, nil
+}
+
+func (f fakeMetadata) GetCalendars(_ context.Context, req *pb.GetCalendarsRequest, _ ...grpc.CallOption) (*pb.GetCalendarsResponse, error) {
+	resp := &pb.GetCalendarsResponse{}
+	for _, id := range req.CubicleIds {
+		resp.Calendars = append(resp.Calendars, &pb.CubicleCalendar{
+			CubicleId: id,
+			Open:      []*pb.TimeInterval{{Start: req.From, End: req.To}},
+		})
+	}
+	return resp, nil
+}
+
+// fakeReservations tiene una reserva por cubículo de bookedBy. ListReservations aplica la
+// misma visibilidad que ReservationService (un estudiante solo ve las suyas), para que una
+// búsqueda que dependa de ella falle.
+type fakeReservations struct {
+	pb.ReservationServiceClient
+	bookedBy map[string]string // cubículo -> usuario
+}
+
+func (f fakeReservations) FindFreeCubicles(_ context.Context, req *pb.FindFreeCubiclesRequest, _ ...grpc.CallOption) (*pb.FindFreeCubiclesResponse, error) {
+	resp := &pb.FindFreeCubiclesResponse{}
+	for _, id := range req.CubicleIds {
+		if _, busy := f.bookedBy[id]; !busy {
+			resp.CubicleIds = append(resp.CubicleIds, id)
+		}
+	}
+	return resp, nil
+}
+
+func (f fakeReservations) ListReservations(ctx context.Context, req *pb.ListReservationsRequest, _ ...grpc.CallOption) (*pb.ListReservationsResponse, error) {
+	resp := &pb.ListReservationsResponse{}
+	p, ok := auth.FromContext(ctx)
+	for cubicleID, userID := range f.bookedBy {
+		if ok && p.HasRole(auth.RoleStudent) && userID != p.Subject {
+			continue
+		}
+		resp.Reservations = append(resp.Reservations, &pb.Reservation{CubicleId: cubicleID, UserId: userID, Start: req.From, End: req.To})
+	}
+	return resp, nil
+}
+
+func TestSearchCubiclesIgnoresCallerIdentity(t *testing.T) {
+	s := &cubicleServer{
+		metaClient: fakeMetadata{cubicles: []*pb.Metadata{
+			{Id: "C-1", Name: "C-1", Capacity: 4},
+			{Id: "C-2", Name: "C-2", Capacity: 4},
+			{Id: "C-3", Name: "C-3", Capacity: 6},
+		}},
+		resClient: fakeReservations{bookedBy: map[string]string{"C-2": "s2", "C-3": "s1"}},
+	}
+	start := time.Now().Add(time.Hour)
+	req := &pb.SearchCubiclesRequest{
+		MinCapacity: 4,
+		Start:       timestamppb.New(start),
+		End:         timestamppb.New(start.Add(2 * time.Hour)),
+	}
+
+	callers := map[string]*auth.Principal{
+		"student s1": {Subject: "s1", Roles: []string{auth.RoleStudent}},
+		"student s2": {Subject: "s2", Roles: []string{auth.RoleStudent}},
+		"librarian":  {Subject: "l1", Roles: []string{auth.RoleLibrarian}},
+		"none":       nil,
+	}
+	for name, p := range callers {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if p != nil {
+				ctx = auth.NewContext(ctx, p)
+			}
+			resp, err := s.SearchCubicles(ctx, req)
+			if err != nil {
+				t.Fatalf("SearchCubicles: %v", err)
+			}
+			var got []string
+			for _, c := range resp.Candidates {
+				got = append(got, c.Metadata.Id)
+			}
+			if want := []string{"C-1"}; !slices.Equal(got, want) {
+				t.Errorf("candidates = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSearchCubiclesRejectsWindowUpFront(t *testing.T) {
+	// Sin clientes: cualquier llamada a metadata o reservation haría fallar la prueba.
+	s := &cubicleServer{}
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		start, end time.Time
+		field      string
+	}{
+		{"empty", start, start, "end"},
+		{"inverted", start, start.Add(-time.Hour), "end"},
+		{"over 31 days", start, start.Add(maxSearchWindow + time.Second), "end"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.SearchCubicles(context.Background(), &pb.SearchCubiclesRequest{
+				Start: timestamppb.New(tt.start),
+				End:   timestamppb.New(tt.end),
+			})
+			st := status.Convert(err)
+			if st.Code() != codes.InvalidArgument {
+				t.Fatalf("SearchCubicles = %v, want InvalidArgument", err)
+			}
+			var fields []string
+			for _, d := range st.Details() {
+				if br, ok := d.(*errdetails.BadRequest); ok {
+					for _, v := range br.FieldViolations {
+						fields = append(fields, v.Field)
+					}
+				}
+			}
+			if !slices.Equal(fields, []string{tt.field}) {
+				t.Errorf("field violations = %v, want [%s]", fields, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,479 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cubiculosup.com/internal/grpcerr"
+	"cubiculosup.com/internal/timerange"
+	pb "cubiculosup.com/proto"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxCalendarRange limita la ventana [from, to) de GetCalendars.
+const maxCalendarRange = 31 * 24 * time.Hour
+
+// dayRange es un rango de apertura en minutos desde la medianoche local.
+type dayRange struct {
+	open, close int
+}
+
+// weeklyHours es el horario de una ubicación; days[time.Weekday] lista sus rangos
+// ordenados y sin traslapes.
+type weeklyHours struct {
+	zone string
+	loc  *time.Location
+	days [7][]dayRange
+}
+
+// openWithin regresa los intervalos abiertos dentro de window, ordenados y con los
+// contiguos unidos (p. ej. 22:00-24:00 con 00:00-06:00 del día siguiente).
+func (h *weeklyHours) openWithin(window timerange.Interval) []timerange.Interval {
+	var out []timerange.Interval
+	y, m, d := window.Start.In(h.loc).Date()
+	for day := time.Date(y, m, d, 0, 0, 0, 0, h.loc); day.Before(window.End); {
+		y, m, d = day.Date()
+		for _, r := range h.days[day.Weekday()] {
+			// time.Date normaliza los minutos, así que los días con cambio de horario
+			// conservan la hora local de apertura y cierre.
+			open := timerange.Interval{
+				Start: time.Date(y, m, d, 0, r.open, 0, 0, h.loc),
+				End:   time.Date(y, m, d, 0, r.close, 0, 0, h.loc),
+			}
+			out = timerange.Append(out, open.Clip(window))
+		}
+		day = time.Date(y, m, d+1, 0, 0, 0, 0, h.loc)
+	}
+	return out
+}
+
+// toProto regresa el horario como OpeningHours de location.
+func (h *weeklyHours) toProto(location string) *pb.OpeningHours {
+	out := &pb.OpeningHours{Location: location, TimeZone: h.zone}
+	for weekday, ranges := range h.days {
+		for _, r := range ranges {
+			out.Days = append(out.Days, &pb.DayHours{
+				Weekday:   int32(weekday),
+				OpenTime:  timerange.FormatClock(r.open),
+				CloseTime: timerange.FormatClock(r.close),
+			})
+		}
+	}
+	return out
+}
+
+// parseOpeningHours valida el horario recibido en SetOpeningHours.
+func parseOpeningHours(req *pb.OpeningHours) (*weeklyHours, error) {
+	if req == nil || req.Location == "" {
+		return nil, status.Error(codes.InvalidArgument, "hours.location is required")
+	}
+
+	h := &weeklyHours{zone: req.TimeZone, loc: time.UTC}
+	if h.zone == "" {
+		h.zone = "UTC"
+	}
+	loc, err := time.LoadLocation(h.zone)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown time zone %q", req.TimeZone)
+	}
+	h.loc = loc
+
+	for i, day := range req.Days {
+		if day.Weekday < 0 || day.Weekday > 6 {
+			return nil, status.Errorf(codes.InvalidArgument, "days[%d].weekday must be between 0 (Sunday) and 6 (Saturday)", i)
+		}
+		open, err := timerange.ParseClock(day.OpenTime)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "days[%d].openTime: %v", i, err)
+		}
+		closeAt, err := timerange.ParseClock(day.CloseTime)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "days[%d].closeTime: %v", i, err)
+		}
+		if open >= closeAt {
+			return nil, status.Errorf(codes.InvalidArgument, "days[%d].closeTime must be after openTime", i)
+		}
+		h.days[day.Weekday] = append(h.days[day.Weekday], dayRange{open, closeAt})
+	}
+
+	for weekday, ranges := range h.days {
+		sort.Slice(ranges, func(i, j int) bool { return ranges[i].open < ranges[j].open })
+		for i := 1; i < len(ranges); i++ {
+			if ranges[i].open < ranges[i-1].close {
+				return nil, status.Errorf(codes.InvalidArgument, "overlapping opening hours on %s", time.Weekday(weekday))
+			}
+		}
+	}
+	return h, nil
+}
+
+// loadHours lee el horario de cada ubicación de locations que tenga uno registrado.
+func (s *metadataServer) loadHours(ctx context.Context, locations []string) (map[string]*weeklyHours, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT c.location, c.time_zone, h.weekday, h.open_minute, h.close_minute
+		FROM location_calendars c
+		LEFT JOIN opening_hours h ON h.location = c.location
+		WHERE c.location = ANY($1)
+		ORDER BY c.location, h.weekday, h.open_minute
+	`, pq.Array(locations))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hours := map[string]*weeklyHours{}
+	for rows.Next() {
+		var (
+			location, zone         string
+			weekday, open, closeAt sql.NullInt64
+		)
+		if err := rows.Scan(&location, &zone, &weekday, &open, &closeAt); err != nil {
+			return nil, err
+		}
+		h := hours[location]
+		if h == nil {
+			loc, err := time.LoadLocation(zone)
+			if err != nil {
+				return nil, fmt.Errorf("time zone of location %s: %w", location, err)
+			}
+			h = &weeklyHours{zone: zone, loc: loc}
+			hours[location] = h
+		}
+		// Sin filas en opening_hours el LEFT JOIN trae NULLs: ubicación cerrada toda la semana.
+		if weekday.Valid {
+			h.days[weekday.Int64] = append(h.days[weekday.Int64], dayRange{int(open.Int64), int(closeAt.Int64)})
+		}
+	}
+	return hours, rows.Err()
+}
+
+func (s *metadataServer) SetOpeningHours(ctx context.Context, req *pb.SetOpeningHoursRequest) (*pb.SetOpeningHoursResponse, error) {
+	h, err := parseOpeningHours(req.Hours)
+	if err != nil {
+		return nil, err
+	}
+	location := req.Hours.Location
+	what := "opening hours of " + location
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO location_calendars (location, time_zone)
+		VALUES ($1, $2)
+		ON CONFLICT (location) DO UPDATE SET time_zone = EXCLUDED.time_zone, updated_at = now()
+	`, location, h.zone); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM opening_hours WHERE location = $1`, location); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	for weekday, ranges := range h.days {
+		for _, r := range ranges {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO opening_hours (location, weekday, open_minute, close_minute)
+				VALUES ($1, $2, $3, $4)
+			`, location, weekday, r.open, r.close); err != nil {
+				return nil, grpcerr.DB(err, what)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	return &pb.SetOpeningHoursResponse{Hours: h.toProto(location)}, nil
+}
+
+func (s *metadataServer) GetOpeningHours(ctx context.Context, req *pb.GetOpeningHoursRequest) (*pb.GetOpeningHoursResponse, error) {
+	what := "opening hours of " + req.Location
+	hours, err := s.loadHours(ctx, []string{req.Location})
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	h, ok := hours[req.Location]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "%s not found; the location is always open", what)
+	}
+	return &pb.GetOpeningHoursResponse{Hours: h.toProto(req.Location)}, nil
+}
+
+func (s *metadataServer) DeleteOpeningHours(ctx context.Context, req *pb.DeleteOpeningHoursRequest) (*pb.DeleteOpeningHoursResponse, error) {
+	what := "opening hours of " + req.Location
+	// opening_hours se borra en cascada.
+	res, err := s.db.ExecContext(ctx, `DELETE FROM location_calendars WHERE location = $1`, req.Location)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		return nil, status.Errorf(codes.NotFound, "%s not found", what)
+	}
+	return &pb.DeleteOpeningHoursResponse{Ok: true}, nil
+}
+
+// closureColumns son las columnas, en orden, que espera scanClosure.
+const closureColumns = `id, location, cubicle_id, start_time, end_time, reason`
+
+func scanClosure(row interface{ Scan(...any) error }) (*pb.Closure, error) {
+	var (
+		c                   pb.Closure
+		location, cubicleID sql.NullString
+		startTime, endTime  time.Time
+	)
+	if err := row.Scan(&c.Id, &location, &cubicleID, &startTime, &endTime, &c.Reason); err != nil {
+		return nil, err
+	}
+	c.Location = location.String
+	c.CubicleId = cubicleID.String
+	c.Start = timestamppb.New(startTime)
+	c.End = timestamppb.New(endTime)
+	return &c, nil
+}
+
+// nullIfEmpty guarda "" como NULL.
+func nullIfEmpty(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func (s *metadataServer) CreateClosure(ctx context.Context, req *pb.CreateClosureRequest) (*pb.CreateClosureResponse, error) {
+	c := req.Closure
+	if c == nil {
+		return nil, status.Error(codes.InvalidArgument, "closure is required")
+	}
+	if (c.Location == "") == (c.CubicleId == "") {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of closure.location and closure.cubicleId is required")
+	}
+	if !c.Start.IsValid() || !c.End.IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "closure.start and closure.end are required")
+	}
+	if !c.Start.AsTime().Before(c.End.AsTime()) {
+		return nil, status.Error(codes.InvalidArgument, "closure.end must be after closure.start")
+	}
+
+	what := "closure of location " + c.Location
+	if c.CubicleId != "" {
+		what = "closure of cubicle " + c.CubicleId
+	}
+
+	row := s.db.QueryRowContext(ctx, `
+		INSERT INTO closures (id, location, cubicle_id, start_time, end_time, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+closureColumns,
+		uuid.NewString(),
+		nullIfEmpty(c.Location),
+		nullIfEmpty(c.CubicleId),
+		c.Start.AsTime(),
+		c.End.AsTime(),
+		c.Reason,
+	)
+	created, err := scanClosure(row)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	return &pb.CreateClosureResponse{Closure: created}, nil
+}
+
+func (s *metadataServer) DeleteClosure(ctx context.Context, req *pb.DeleteClosureRequest) (*pb.DeleteClosureResponse, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM closures WHERE id = $1`, req.ClosureId)
+	if err != nil {
+		return nil, grpcerr.DB(err, "closure "+req.ClosureId)
+	}
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		return nil, status.Errorf(codes.NotFound, "closure %s not found", req.ClosureId)
+	}
+	return &pb.DeleteClosureResponse{Ok: true}, nil
+}
+
+func (s *metadataServer) ListClosures(ctx context.Context, req *pb.ListClosuresRequest) (*pb.ListClosuresResponse, error) {
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if req.Location != "" {
+		where = append(where, "location = "+arg(req.Location))
+	}
+	if req.CubicleId != "" {
+		where = append(where, "cubicle_id = "+arg(req.CubicleId))
+	}
+	if req.From != nil {
+		if !req.From.IsValid() {
+			return nil, status.Error(codes.InvalidArgument, "from is not a valid timestamp")
+		}
+		where = append(where, "end_time > "+arg(req.From.AsTime()))
+	}
+	if req.To != nil {
+		if !req.To.IsValid() {
+			return nil, status.Error(codes.InvalidArgument, "to is not a valid timestamp")
+		}
+		where = append(where, "start_time < "+arg(req.To.AsTime()))
+	}
+
+	query := `
+		SELECT ` + closureColumns + `
+		FROM closures`
+	if len(where) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(where, " AND ")
+	}
+	query += "\n\t\tORDER BY start_time, id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, grpcerr.DB(err, "closure list")
+	}
+	defer rows.Close()
+
+	resp := &pb.ListClosuresResponse{}
+	for rows.Next() {
+		c, err := scanClosure(rows)
+		if err != nil {
+			return nil, grpcerr.DB(err, "closure list")
+		}
+		resp.Closures = append(resp.Closures, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, grpcerr.DB(err, "closure list")
+	}
+	return resp, nil
+}
+
+// GetCalendars calcula, para cada cubículo, los intervalos abiertos dentro de la ventana:
+// el horario de su ubicación (o todo el día si no tiene) menos los cierres de la ubicación
+// y los del cubículo.
+func (s *metadataServer) GetCalendars(ctx context.Context, req *pb.GetCalendarsRequest) (*pb.GetCalendarsResponse, error) {
+	if len(req.CubicleIds) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d cubicleIds per call", maxBatchSize)
+	}
+	if !req.From.IsValid() || !req.To.IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "from and to are required")
+	}
+	window := timerange.Interval{Start: req.From.AsTime(), End: req.To.AsTime()}
+	if !window.Start.Before(window.End) {
+		return nil, status.Error(codes.InvalidArgument, "to must be after from")
+	}
+	if window.End.Sub(window.Start) > maxCalendarRange {
+		return nil, status.Errorf(codes.InvalidArgument, "range must not exceed %s", maxCalendarRange)
+	}
+	if len(req.CubicleIds) == 0 {
+		return &pb.GetCalendarsResponse{}, nil
+	}
+
+	const what = "calendar batch"
+
+	// 1. Ubicación de cada cubículo.
+	rows, err := s.db.QueryContext(ctx, `SELECT id, location FROM metadata WHERE id = ANY($1)`, pq.Array(req.CubicleIds))
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	defer rows.Close()
+
+	locationOf := map[string]string{}
+	var locations []string
+	for rows.Next() {
+		var id, location string
+		if err := rows.Scan(&id, &location); err != nil {
+			return nil, grpcerr.DB(err, what)
+		}
+		locationOf[id] = location
+		locations = append(locations, location)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	// 2. Horarios de esas ubicaciones.
+	hours, err := s.loadHours(ctx, locations)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	// 3. Cierres que tocan la ventana.
+	closureRows, err := s.db.QueryContext(ctx, `
+		SELECT `+closureColumns+`
+		FROM closures
+		WHERE (location = ANY($1) OR cubicle_id = ANY($2))
+		  AND start_time < $4 AND end_time > $3
+		ORDER BY start_time, id
+	`, pq.Array(locations), pq.Array(req.CubicleIds), window.Start, window.End)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	defer closureRows.Close()
+
+	byLocation := map[string][]*pb.Closure{}
+	byCubicle := map[string][]*pb.Closure{}
+	for closureRows.Next() {
+		c, err := scanClosure(closureRows)
+		if err != nil {
+			return nil, grpcerr.DB(err, what)
+		}
+		if c.CubicleId != "" {
+			byCubicle[c.CubicleId] = append(byCubicle[c.CubicleId], c)
+		} else {
+			byLocation[c.Location] = append(byLocation[c.Location], c)
+		}
+	}
+	if err := closureRows.Err(); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	// Respeta el orden pedido y reporta cada id inexistente una sola vez.
+	resp := &pb.GetCalendarsResponse{}
+	seen := map[string]bool{}
+	for _, id := range req.CubicleIds {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		location, ok := locationOf[id]
+		if !ok {
+			resp.Errors = append(resp.Errors, &pb.ItemError{
+				Id:      id,
+				Code:    int32(codes.NotFound),
+				Message: "cubicle " + id + " not found",
+			})
+			continue
+		}
+
+		cal := &pb.CubicleCalendar{CubicleId: id, Location: location, TimeZone: "UTC"}
+		open := []timerange.Interval{window}
+		if h, ok := hours[location]; ok {
+			cal.TimeZone = h.zone
+			open = h.openWithin(window)
+		}
+
+		cal.Closures = append(append(cal.Closures, byLocation[location]...), byCubicle[id]...)
+		closed := make([]timerange.Interval, len(cal.Closures))
+		for i, c := range cal.Closures {
+			closed[i] = timerange.Interval{Start: c.Start.AsTime(), End: c.End.AsTime()}
+		}
+
+		for _, o := range timerange.Subtract(open, closed) {
+			cal.Open = append(cal.Open, &pb.TimeInterval{
+				Start: timestamppb.New(o.Start),
+				End:   timestamppb.New(o.End),
+			})
+		}
+		resp.Calendars = append(resp.Calendars, cal)
+	}
+	return resp, nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cubiculosup.com/internal/grpcerr"
+	"cubiculosup.com/internal/pagetoken"
+	pb "cubiculosup.com/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+// listOrders define, para cada valor de orderBy, las columnas de ORDER BY. La última
+// siempre es id para que el orden sea total y la paginación por llave sea estable.
+var listOrders = map[string][]string{
+	"name":     {"name", "id"},
+	"location": {"location", "name", "id"},
+	"capacity": {"capacity", "id"},
+}
+
+// sortValue regresa el valor de la columna col de m como texto para el cursor.
+func sortValue(m *pb.Metadata, col string) string {
+	switch col {
+	case "name":
+		return m.Name
+	case "location":
+		return m.Location
+	case "capacity":
+		return strconv.Itoa(int(m.Capacity))
+	default:
+		return m.Id
+	}
+}
+
+// escapeLike escapa los comodines de LIKE para buscar prefix literalmente.
+func escapeLike(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+}
+
+func (s *metadataServer) ListMetadata(ctx context.Context, req *pb.ListMetadataRequest) (*pb.ListMetadataResponse, error) {
+	orderBy := req.OrderBy
+	if orderBy == "" {
+		orderBy = "name"
+	}
+	columns, ok := listOrders[orderBy]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "orderBy must be one of name, location, capacity; got %q", req.OrderBy)
+	}
+
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize < 0:
+		return nil, status.Error(codes.InvalidArgument, "pageSize must not be negative")
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+
+	if req.MinCapacity < 0 {
+		return nil, status.Error(codes.InvalidArgument, "minCapacity must not be negative")
+	}
+
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if !req.IncludeArchived {
+		where = append(where, "NOT archived")
+	}
+	if req.Location != "" {
+		where = append(where, "location = "+arg(req.Location))
+	}
+	if req.MinCapacity > 0 {
+		where = append(where, "capacity >= "+arg(req.MinCapacity))
+	}
+	if req.NamePrefix != "" {
+		where = append(where, "name LIKE "+arg(escapeLike(req.NamePrefix)+"%"))
+	}
+
+	// El cursor de la página son los valores de las columnas de orden de la última fila;
+	// el token queda ligado a estos filtros.
+	filters := []any{orderBy, req.Location, req.MinCapacity, req.NamePrefix, req.IncludeArchived}
+
+	if req.PageToken != "" {
+		var values []string
+		if err := pagetoken.Decode(req.PageToken, &values, filters...); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if len(values) != len(columns) {
+			return nil, status.Error(codes.InvalidArgument, pagetoken.ErrMalformed.Error())
+		}
+
+		// Comparación de tuplas: (c1, c2, id) > (v1, v2, vid) sigue exactamente el ORDER BY.
+		placeholders := make([]string, len(columns))
+		for i, col := range columns {
+			if col == "capacity" {
+				capacity, err := strconv.Atoi(values[i])
+				if err != nil {
+					return nil, status.Error(codes.InvalidArgument, pagetoken.ErrMalformed.Error())
+				}
+				placeholders[i] = arg(capacity)
+			} else {
+				placeholders[i] = arg(values[i])
+			}
+		}
+		where = append(where, fmt.Sprintf("(%s) > (%s)", strings.Join(columns, ", "), strings.Join(placeholders, ", ")))
+	}
+
+	query := `
+		SELECT id, name, location, capacity, archived
+		FROM metadata`
+	if len(where) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(where, " AND ")
+	}
+	query += fmt.Sprintf("\n\t\tORDER BY %s\n\t\tLIMIT %s", strings.Join(columns, ", "), arg(pageSize+1))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, grpcerr.DB(err, "cubicle list")
+	}
+	defer rows.Close()
+
+	var list []*pb.Metadata
+	for rows.Next() {
+		var m pb.Metadata
+		if err := rows.Scan(&m.Id, &m.Name, &m.Location, &m.Capacity, &m.Archived); err != nil {
+			return nil, grpcerr.DB(err, "cubicle list")
+		}
+		list = append(list, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, grpcerr.DB(err, "cubicle list")
+	}
+
+	resp := &pb.ListMetadataResponse{}
+	if len(list) > pageSize {
+		// Se pidió una fila extra solo para saber si hay otra página.
+		list = list[:pageSize]
+		last := list[len(list)-1]
+		values := make([]string, len(columns))
+		for i, col := range columns {
+			values[i] = sortValue(last, col)
+		}
+		resp.NextPageToken = pagetoken.Encode(values, filters...)
+	}
+	resp.Metadata = list
+
+	return resp, nil
+}
@@ -7,32 +7,52 @@ import (
 	"log"
 	"net"
 	"os"
+	"strings"
 
+	"cubiculosup.com/internal/auth"
+	"cubiculosup.com/internal/config"
+	"cubiculosup.com/internal/graceful"
+	"cubiculosup.com/internal/grpcerr"
+	"cubiculosup.com/internal/healthcheck"
+	"cubiculosup.com/internal/migrate"
+	"cubiculosup.com/internal/tlsconfig"
 	pb "cubiculosup.com/proto"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
+// methodRoles reserva a los administradores los cambios al catálogo de cubículos y a sus
+// horarios; las consultas quedan abiertas a cualquier usuario autenticado.
+var methodRoles = auth.Policy{
+	pb.MetadataService_CreateMetadata_FullMethodName:     {auth.RoleAdmin},
+	pb.MetadataService_UpdateMetadata_FullMethodName:     {auth.RoleAdmin},
+	pb.MetadataService_DeleteMetadata_FullMethodName:     {auth.RoleAdmin},
+	pb.MetadataService_SetOpeningHours_FullMethodName:    {auth.RoleAdmin},
+	pb.MetadataService_DeleteOpeningHours_FullMethodName: {auth.RoleAdmin},
+	pb.MetadataService_CreateClosure_FullMethodName:      {auth.RoleAdmin},
+	pb.MetadataService_DeleteClosure_FullMethodName:      {auth.RoleAdmin},
+}
+
 type metadataServer struct {
 	pb.UnimplementedMetadataServiceServer
 	db *sql.DB
 }
 
 func (s *metadataServer) GetMetadata(ctx context.Context, req *pb.GetMetadataRequest) (*pb.GetMetadataResponse, error) {
-	row := s.db.QueryRow(`
-		SELECT id, name, location, capacity
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, name, location, capacity, archived
 		FROM metadata
 		WHERE id = $1
 	`, req.CubicleId)
 
 	var m pb.Metadata
-	err := row.Scan(&m.Id, &m.Name, &m.Location, &m.Capacity)
-	if err == sql.ErrNoRows {
-		return nil, err
-	} else if err != nil {
-		return nil, err
+	if err := row.Scan(&m.Id, &m.Name, &m.Location, &m.Capacity, &m.Archived); err != nil {
+		return nil, grpcerr.DB(err, "cubicle "+req.CubicleId)
 	}
 
 	return &pb.GetMetadataResponse{Metadata: &m}, nil
@@ -40,57 +60,275 @@ func (s *metadataServer) GetMetadata(ctx context.Context, req *pb.GetMetadataReq
 
 func (s *metadataServer) CreateMetadata(ctx context.Context, req *pb.CreateMetadataRequest) (*pb.CreateMetadataResponse, error) {
 	meta := req.Metadata
-	_, err := s.db.Exec(`
+	if meta == nil || meta.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "metadata.id is required")
+	}
+	for _, path := range []string{"name", "location", "capacity"} {
+		if err := checkMetadataField(path, meta); err != nil {
+			return nil, err
+		}
+	}
+	_, err := s.db.ExecContext(ctx, `
 		INSERT INTO metadata (id, name, location, capacity)
 		VALUES ($1, $2, $3, $4)
 	`, meta.Id, meta.Name, meta.Location, meta.Capacity)
 	if err != nil {
-		return nil, err
+		return nil, grpcerr.DB(err, "cubicle "+meta.Id)
 	}
 	return &pb.CreateMetadataResponse{CubicleId: meta.Id}, nil
 }
 
-func main() {
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbName := os.Getenv("DB_NAME")
-
-	const dbHostIP = "10.96.128.214"
-	const dbPort = "5432"
-
-	// Construye la URL de conexión de PostgreSQL
-	dbURL := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
-		dbUser,
-		dbPassword,
-		dbHostIP,
-		dbPort,
-		dbName,
+// checkMetadataField aplica a un campo de meta las mismas reglas en CreateMetadata y en
+// cada ruta del FieldMask de UpdateMetadata.
+func checkMetadataField(path string, meta *pb.Metadata) error {
+	switch path {
+	case "name":
+		if strings.TrimSpace(meta.Name) == "" {
+			return status.Error(codes.InvalidArgument, "metadata.name must not be empty")
+		}
+	case "location":
+		if strings.TrimSpace(meta.Location) == "" {
+			return status.Error(codes.InvalidArgument, "metadata.location must not be empty")
+		}
+	case "capacity":
+		if meta.Capacity <= 0 {
+			return status.Error(codes.InvalidArgument, "metadata.capacity must be positive")
+		}
+	}
+	return nil
+}
+
+// updatableColumns mapea las rutas permitidas del FieldMask de UpdateMetadata a columnas.
+var updatableColumns = map[string]string{
+	"name":     "name",
+	"location": "location",
+	"capacity": "capacity",
+	"archived": "archived",
+}
+
+func (s *metadataServer) UpdateMetadata(ctx context.Context, req *pb.UpdateMetadataRequest) (*pb.UpdateMetadataResponse, error) {
+	meta := req.Metadata
+	if meta == nil || meta.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "metadata.id is required")
+	}
+
+	paths := req.UpdateMask.GetPaths()
+	if len(paths) == 0 {
+		paths = []string{"name", "location", "capacity"}
+	}
+
+	var (
+		sets []string
+		args = []any{meta.Id}
 	)
+	seen := map[string]bool{}
+	for _, path := range paths {
+		column, ok := updatableColumns[path]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "updateMask path %q is not updatable", path)
+		}
+		if seen[column] {
+			continue
+		}
+		seen[column] = true
+		if err := checkMetadataField(path, meta); err != nil {
+			return nil, err
+		}
+
+		var value any
+		switch path {
+		case "name":
+			value = meta.Name
+		case "location":
+			value = meta.Location
+		case "capacity":
+			value = meta.Capacity
+		case "archived":
+			value = meta.Archived
+			// archived_at se mantiene en sincronía con archived.
+			sets = append(sets, fmt.Sprintf("archived_at = CASE WHEN $%d THEN COALESCE(archived_at, now()) END", len(args)+1))
+		}
+		args = append(args, value)
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	row := s.db.QueryRowContext(ctx, `
+		UPDATE metadata
+		SET `+strings.Join(sets, ", ")+`
+		WHERE id = $1
+		RETURNING id, name, location, capacity, archived
+	`, args...)
+
+	var m pb.Metadata
+	if err := row.Scan(&m.Id, &m.Name, &m.Location, &m.Capacity, &m.Archived); err != nil {
+		return nil, grpcerr.DB(err, "cubicle "+meta.Id)
+	}
+
+	return &pb.UpdateMetadataResponse{Metadata: &m}, nil
+}
 
-	// Abre la conexión a la base de datos
-	db, err := sql.Open("postgres", dbURL)
+// DeleteMetadata archiva el cubículo en lugar de borrarlo: las reservas históricas siguen
+// apuntando a él y ReservationService deja de aceptar reservas nuevas.
+func (s *metadataServer) DeleteMetadata(ctx context.Context, req *pb.DeleteMetadataRequest) (*pb.DeleteMetadataResponse, error) {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE metadata
+		SET archived = true, archived_at = COALESCE(archived_at, now())
+		WHERE id = $1
+	`, req.CubicleId)
 	if err != nil {
-		log.Fatalf("cannot open db: %v", err)
+		return nil, grpcerr.DB(err, "cubicle "+req.CubicleId)
+	}
+
+	affected, _ := res.RowsAffected()
+	if affected == 0 {
+		return nil, status.Errorf(codes.NotFound, "cubicle %s not found", req.CubicleId)
 	}
+	return &pb.DeleteMetadataResponse{Ok: true}, nil
+}
+
+// maxBatchSize limita los ids por llamada de BatchGetMetadata.
+const maxBatchSize = 500
 
-	// Test connection
-	if err := db.Ping(); err != nil {
-		log.Fatalf("ping error: %v", err)
+func (s *metadataServer) BatchGetMetadata(ctx context.Context, req *pb.BatchGetMetadataRequest) (*pb.BatchGetMetadataResponse, error) {
+	if len(req.CubicleIds) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d cubicleIds per call", maxBatchSize)
+	}
+	if len(req.CubicleIds) == 0 {
+		return &pb.BatchGetMetadataResponse{}, nil
 	}
-	// --- FIN DEL CÓDIGO DE CONEXIÓN A DB ---
 
-	lis, err := net.Listen("tcp", ":50051")
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, name, location, capacity, archived
+		FROM metadata
+		WHERE id = ANY($1)
+	`, pq.Array(req.CubicleIds))
+	if err != nil {
+		return nil, grpcerr.DB(err, "cubicle batch")
+	}
+	defer rows.Close()
+
+	found := map[string]*pb.Metadata{}
+	for rows.Next() {
+		var m pb.Metadata
+		if err := rows.Scan(&m.Id, &m.Name, &m.Location, &m.Capacity, &m.Archived); err != nil {
+			return nil, grpcerr.DB(err, "cubicle batch")
+		}
+		found[m.Id] = &m
+	}
+	if err := rows.Err(); err != nil {
+		return nil, grpcerr.DB(err, "cubicle batch")
+	}
+
+	// Respeta el orden pedido y reporta cada id inexistente una sola vez.
+	resp := &pb.BatchGetMetadataResponse{}
+	seen := map[string]bool{}
+	for _, id := range req.CubicleIds {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if m, ok := found[id]; ok {
+			resp.Metadata = append(resp.Metadata, m)
+		} else {
+			resp.Errors = append(resp.Errors, &pb.ItemError{
+				Id:      id,
+				Code:    int32(codes.NotFound),
+				Message: "cubicle " + id + " not found",
+			})
+		}
+	}
+	return resp, nil
+}
+
+func main() {
+	loader := config.NewLoader("metadata")
+	listen := loader.Listen(50051)
+	dbCfg := loader.DB()
+	healthCfg := loader.Health()
+	shutdownCfg := loader.Shutdown()
+	var migrateOnStart bool
+	loader.Bool(&migrateOnStart, "migrate-on-start", "MIGRATE_ON_START", true, "apply pending migrations at startup")
+	if err := loader.Load(os.Args[1:]); err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+	// ctx termina con SIGTERM: detiene los trabajos en segundo plano y drena el servidor.
+	ctx := graceful.SignalContext()
+
+	db, err := dbCfg.Open(context.Background())
+	if err != nil {
+		log.Fatalf("cannot connect to db: %v", err)
+	}
+
+	// "migrate up|down [n]|status" administra el esquema y termina.
+	if args := loader.Args(); len(args) > 0 && args[0] == "migrate" {
+		if err := migrate.RunCommand(context.Background(), db, args[1:], os.Stdout); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+
+	// Por defecto cada réplica aplica las migraciones pendientes al arrancar;
+	// el advisory lock hace que solo una las ejecute.
+	if migrateOnStart {
+		runner, err := migrate.New(db)
+		if err != nil {
+			log.Fatalf("cannot load migrations: %v", err)
+		}
+		if _, err := runner.Up(context.Background()); err != nil {
+			log.Fatalf("cannot migrate database: %v", err)
+		}
+	}
+
+	lis, err := net.Listen("tcp", listen.Addr())
 	if err != nil {
 		log.Fatalf("error listening: %v", err)
 	}
 
-	s := grpc.NewServer()
+	authCfg := auth.ConfigFromEnv()
+	verifier, err := auth.NewVerifier(authCfg)
+	if err != nil {
+		log.Fatalf("cannot configure authentication: %v", err)
+	}
+	if verifier.Disabled() {
+		log.Println("WARNING: authentication is disabled (AUTH_DISABLED=true)")
+	}
+
+	tlsCfg := tlsconfig.FromEnv()
+	serverCreds, err := tlsconfig.ServerCredentials(tlsCfg)
+	if err != nil {
+		log.Fatalf("cannot configure TLS: %v", err)
+	}
+	if tlsCfg.Insecure {
+		log.Println("WARNING: serving without TLS (TLS_INSECURE=true)")
+	}
+
+	s := grpc.NewServer(
+		grpc.Creds(serverCreds),
+		grpc.ChainUnaryInterceptor(verifier.UnaryServerInterceptor(), methodRoles.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(verifier.StreamServerInterceptor(), methodRoles.StreamServerInterceptor()),
+	)
 	pb.RegisterMetadataServiceServer(s, &metadataServer{db: db})
 	reflection.Register(s)
 
-	log.Println("Metadata service running with PostgreSQL on port 50051")
-	if err := s.Serve(lis); err != nil {
+	// Readiness: "" y MetadataService dejan de servir si se pierde Postgres.
+	hs := healthcheck.NewServer(pb.MetadataService_ServiceDesc.ServiceName)
+	healthpb.RegisterHealthServer(s, hs)
+	go healthcheck.Watch(ctx, hs, healthCfg.Interval, []healthcheck.Check{healthcheck.DB(db)}, pb.MetadataService_ServiceDesc.ServiceName)
+	var probes *grpc.Server
+	if healthCfg.Port != 0 {
+		if probes, err = healthcheck.Serve(healthCfg.Addr(), hs); err != nil {
+			log.Fatalf("cannot serve health checks: %v", err)
+		}
+	}
+
+	log.Printf("Metadata service running with PostgreSQL on port %d", listen.Port)
+	if err := graceful.Serve(ctx, s, lis, hs, shutdownCfg.Delay, shutdownCfg.Timeout); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 
+	if probes != nil {
+		probes.Stop()
+	}
+	graceful.Close("database", db)
+	log.Println("Metadata service stopped")
 }
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"cubiculosup.com/internal/auth"
+	pb "cubiculosup.com/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMethodRolesAdminOnlyWrites(t *testing.T) {
+	interceptor := methodRoles.UnaryServerInterceptor()
+	handler := func(context.Context, any) (any, error) { return nil, nil }
+
+	tests := []struct {
+		method string
+		role   string
+		want   codes.Code
+	}{
+		{pb.MetadataService_CreateMetadata_FullMethodName, auth.RoleStudent, codes.PermissionDenied},
+		{pb.MetadataService_CreateMetadata_FullMethodName, auth.RoleLibrarian, codes.PermissionDenied},
+		{pb.MetadataService_CreateMetadata_FullMethodName, auth.RoleAdmin, codes.OK},
+		{pb.MetadataService_UpdateMetadata_FullMethodName, auth.RoleStudent, codes.PermissionDenied},
+		{pb.MetadataService_SetOpeningHours_FullMethodName, auth.RoleLibrarian, codes.PermissionDenied},
+		{pb.MetadataService_CreateClosure_FullMethodName, auth.RoleAdmin, codes.OK},
+		{pb.MetadataService_GetMetadata_FullMethodName, auth.RoleStudent, codes.OK},
+		{pb.MetadataService_GetCalendars_FullMethodName, auth.ServiceRole, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role+tt.method, func(t *testing.T) {
+			ctx := auth.NewContext(context.Background(), &auth.Principal{Subject: "u1", Roles: []string{tt.role}})
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("%s as %s = %v, want %v", tt.method, tt.role, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMetadataField(t *testing.T) {
+	valid := &pb.Metadata{Id: "C-1", Name: "Cubículo 1", Location: "Biblioteca central", Capacity: 4}
+	tests := []struct {
+		path string
+		meta *pb.Metadata
+		want codes.Code
+	}{
+		{"name", valid, codes.OK},
+		{"name", &pb.Metadata{Name: ""}, codes.InvalidArgument},
+		{"name", &pb.Metadata{Name: "  "}, codes.InvalidArgument},
+		{"location", valid, codes.OK},
+		{"location", &pb.Metadata{Location: ""}, codes.InvalidArgument},
+		{"capacity", valid, codes.OK},
+		{"capacity", &pb.Metadata{Capacity: 0}, codes.InvalidArgument},
+		{"capacity", &pb.Metadata{Capacity: -2}, codes.InvalidArgument},
+		// archived no tiene reglas: cualquier valor es válido.
+		{"archived", &pb.Metadata{}, codes.OK},
+	}
+	for _, tt := range tests {
+		if got := status.Code(checkMetadataField(tt.path, tt.meta)); got != tt.want {
+			t.Errorf("checkMetadataField(%q, %v) = %v, want %v", tt.path, tt.meta, got, tt.want)
+		}
+	}
+}
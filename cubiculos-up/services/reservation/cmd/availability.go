@@ -0,0 +1,342 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"cubiculosup.com/internal/grpcerr"
+	"cubiculosup.com/internal/timerange"
+	pb "cubiculosup.com/proto"
+
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxFreeIntervalsRange limita el rango que puede pedir ListFreeIntervals.
+const maxFreeIntervalsRange = 31 * 24 * time.Hour
+
+// blockingStatuses son los estados que ocupan el cubículo; los demás son finales.
+var blockingStatuses = []string{statusPending, statusConfirmed, statusCheckedIn}
+
+// availabilityIn calcula la disponibilidad a partir de window.Start (ahora): el primer
+// momento de window en que el cubículo está abierto (open, ordenado) y ninguna reserva de
+// busy lo ocupa. Sin hueco en window, nextAvailable queda vacío. busy puede venir
+// desordenado.
+func availabilityIn(window timerange.Interval, busy, open []timerange.Interval) *pb.Availability {
+	free := timerange.Intersect(timerange.Subtract([]timerange.Interval{window}, busy), open)
+	if len(free) == 0 {
+		return &pb.Availability{}
+	}
+	return &pb.Availability{
+		AvailableNow:  free[0].Start.Equal(window.Start),
+		NextAvailable: timestamppb.New(free[0].Start),
+	}
+}
+
+// localMidnight regresa el inicio del día de t en loc.
+func localMidnight(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// dailyHours es un horario de apertura que se repite todos los días en hora local.
+type dailyHours struct {
+	open, close int // minutos desde la medianoche local
+	loc         *time.Location
+}
+
+// windows regresa los intervalos en que está abierto dentro de window. time.Date normaliza
+// los minutos, así que los días con cambio de horario conservan la hora local de apertura
+// y cierre.
+func (h dailyHours) windows(window timerange.Interval) []timerange.Interval {
+	var out []timerange.Interval
+	y, m, d := window.Start.In(h.loc).Date()
+	for day := time.Date(y, m, d, 0, 0, 0, 0, h.loc); day.Before(window.End); {
+		y, m, d = day.Date()
+		open := timerange.Interval{
+			Start: time.Date(y, m, d, 0, h.open, 0, 0, h.loc),
+			End:   time.Date(y, m, d, 0, h.close, 0, 0, h.loc),
+		}
+		out = timerange.Append(out, open.Clip(window))
+		day = time.Date(y, m, d+1, 0, 0, 0, 0, h.loc)
+	}
+	return out
+}
+
+// wallClock regresa la fecha de t en loc y la hora que marca el reloj local, como tiempo
+// desde la medianoche. En un día con cambio de horario no coincide con t - medianoche.
+func wallClock(t time.Time, loc *time.Location) (y int, m time.Month, d int, clock time.Duration) {
+	t = t.In(loc)
+	y, m, d = t.Date()
+	clock = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+	return y, m, d, clock
+}
+
+// snapToSlots recorta cada intervalo a fronteras de hora local múltiplo de slot contadas
+// desde la medianoche (10:00, 10:30, ...) y descarta los que no alcanzan un slot completo.
+func snapToSlots(free []timerange.Interval, slot time.Duration, loc *time.Location) []timerange.Interval {
+	var out []timerange.Interval
+	for _, f := range free {
+		y, m, d, clock := wallClock(f.Start, loc)
+		start := time.Date(y, m, d, 0, 0, 0, int((clock+slot-1)/slot*slot), loc)
+
+		y, m, d, clock = wallClock(f.End, loc)
+		end := time.Date(y, m, d, 0, 0, 0, int(clock/slot*slot), loc)
+
+		if end.Sub(start) >= slot {
+			out = append(out, timerange.Interval{Start: start, End: end})
+		}
+	}
+	return out
+}
+
+// loadBusy regresa los intervalos ocupados del cubículo que se traslapan con window.
+func (s *reservationServer) loadBusy(ctx context.Context, cubicleID string, window timerange.Interval) ([]timerange.Interval, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT start_time, end_time
+		FROM reservations
+		WHERE cubicle_id = $1 AND status = ANY($2::reservation_status[])
+		  AND start_time < $4 AND end_time > $3
+		ORDER BY start_time
+	`, cubicleID, pq.Array(blockingStatuses), window.Start, window.End)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var busy []timerange.Interval
+	for rows.Next() {
+		var b timerange.Interval
+		if err := rows.Scan(&b.Start, &b.End); err != nil {
+			return nil, err
+		}
+		busy = append(busy, b)
+	}
+	return busy, rows.Err()
+}
+
+// maxBatchSize limita los ids por llamada de BatchCheckAvailability.
+const maxBatchSize = 500
+
+func (s *reservationServer) BatchCheckAvailability(ctx context.Context, req *pb.BatchCheckAvailabilityRequest) (*pb.BatchCheckAvailabilityResponse, error) {
+	if len(req.CubicleIds) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d cubicleIds per call", maxBatchSize)
+	}
+	if len(req.CubicleIds) == 0 {
+		return &pb.BatchCheckAvailabilityResponse{}, nil
+	}
+
+	now := time.Now().In(time.UTC)
+	window := timerange.Interval{Start: now, End: now.Add(availabilityHorizon)}
+
+	calendars, err := s.loadCalendars(ctx, req.CubicleIds, window)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT cubicle_id, start_time, end_time
+		FROM reservations
+		WHERE cubicle_id = ANY($1) AND status = ANY($2::reservation_status[])
+		  AND start_time < $4 AND end_time > $3
+	`, pq.Array(req.CubicleIds), pq.Array(blockingStatuses), window.Start, window.End)
+	if err != nil {
+		return nil, grpcerr.DB(err, "availability batch")
+	}
+	defer rows.Close()
+
+	busy := map[string][]timerange.Interval{}
+	for rows.Next() {
+		var (
+			cubicleID string
+			b         timerange.Interval
+		)
+		if err := rows.Scan(&cubicleID, &b.Start, &b.End); err != nil {
+			return nil, grpcerr.DB(err, "availability batch")
+		}
+		busy[cubicleID] = append(busy[cubicleID], b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, grpcerr.DB(err, "availability batch")
+	}
+
+	resp := &pb.BatchCheckAvailabilityResponse{}
+	seen := map[string]bool{}
+	for _, id := range req.CubicleIds {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		cal, ok := calendars[id]
+		if !ok {
+			resp.Errors = append(resp.Errors, &pb.ItemError{
+				Id:      id,
+				Code:    int32(codes.NotFound),
+				Message: "cubicle " + id + " not found",
+			})
+			continue
+		}
+		resp.Availability = append(resp.Availability, &pb.CubicleAvailability{
+			CubicleId:    id,
+			Availability: availabilityIn(window, busy[id], openIntervals(cal)),
+		})
+	}
+	return resp, nil
+}
+
+// FindFreeCubicles regresa los cubículos de la petición que no tienen ninguna reserva
+// activa en [from, to). Es la consulta de SearchCubicles: solo dice qué ids están libres,
+// así que no se filtra por el usuario que llama.
+func (s *reservationServer) FindFreeCubicles(ctx context.Context, req *pb.FindFreeCubiclesRequest) (*pb.FindFreeCubiclesResponse, error) {
+	var br badRequest
+
+	if len(req.CubicleIds) > maxBatchSize {
+		br.add("cubicleIds", "at most %d cubicleIds per call", maxBatchSize)
+	}
+	if req.From == nil || req.From.CheckValid() != nil {
+		br.add("from", "from must be a valid timestamp")
+	}
+	if req.To == nil || req.To.CheckValid() != nil {
+		br.add("to", "to must be a valid timestamp")
+	}
+	if req.From.IsValid() && req.To.IsValid() && !req.From.AsTime().Before(req.To.AsTime()) {
+		br.add("to", "to must be after from")
+	}
+	if err := br.err(); err != nil {
+		return nil, err
+	}
+	if len(req.CubicleIds) == 0 {
+		return &pb.FindFreeCubiclesResponse{}, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT cubicle_id
+		FROM reservations
+		WHERE cubicle_id = ANY($1) AND status = ANY($2::reservation_status[])
+		  AND start_time < $4 AND end_time > $3
+	`, pq.Array(req.CubicleIds), pq.Array(blockingStatuses), req.From.AsTime(), req.To.AsTime())
+	if err != nil {
+		return nil, grpcerr.DB(err, "free cubicles")
+	}
+	defer rows.Close()
+
+	busy := map[string]bool{}
+	for rows.Next() {
+		var cubicleID string
+		if err := rows.Scan(&cubicleID); err != nil {
+			return nil, grpcerr.DB(err, "free cubicles")
+		}
+		busy[cubicleID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, grpcerr.DB(err, "free cubicles")
+	}
+
+	resp := &pb.FindFreeCubiclesResponse{}
+	seen := map[string]bool{}
+	for _, id := range req.CubicleIds {
+		if seen[id] || busy[id] {
+			continue
+		}
+		seen[id] = true
+		resp.CubicleIds = append(resp.CubicleIds, id)
+	}
+	return resp, nil
+}
+
+func (s *reservationServer) ListFreeIntervals(ctx context.Context, req *pb.ListFreeIntervalsRequest) (*pb.ListFreeIntervalsResponse, error) {
+	var br badRequest
+
+	if req.CubicleId == "" {
+		br.add("cubicleId", "cubicleId is required")
+	}
+	if req.From == nil || req.From.CheckValid() != nil {
+		br.add("from", "from must be a valid timestamp")
+	}
+	if req.To == nil || req.To.CheckValid() != nil {
+		br.add("to", "to must be a valid timestamp")
+	}
+
+	var window timerange.Interval
+	if req.From.IsValid() && req.To.IsValid() {
+		window = timerange.Interval{Start: req.From.AsTime(), End: req.To.AsTime()}
+		if !window.Start.Before(window.End) {
+			br.add("to", "to must be after from")
+		} else if window.End.Sub(window.Start) > maxFreeIntervalsRange {
+			br.add("to", "range must not exceed %s", maxFreeIntervalsRange)
+		}
+	}
+
+	var slot time.Duration
+	if req.SlotDuration != nil {
+		if err := req.SlotDuration.CheckValid(); err != nil || req.SlotDuration.AsDuration() <= 0 {
+			br.add("slotDuration", "slotDuration must be positive")
+		} else {
+			slot = req.SlotDuration.AsDuration()
+		}
+	}
+
+	loc := time.UTC
+	if req.TimeZone != "" {
+		l, err := time.LoadLocation(req.TimeZone)
+		if err != nil {
+			br.add("timeZone", "unknown time zone %q", req.TimeZone)
+		} else {
+			loc = l
+		}
+	}
+
+	var hours *dailyHours
+	if req.OpenTime != "" || req.CloseTime != "" {
+		open, errOpen := timerange.ParseClock(req.OpenTime)
+		if errOpen != nil {
+			br.add("openTime", "%v", errOpen)
+		}
+		closeAt, errClose := timerange.ParseClock(req.CloseTime)
+		if errClose != nil {
+			br.add("closeTime", "%v", errClose)
+		}
+		if errOpen == nil && errClose == nil {
+			if open >= closeAt {
+				br.add("closeTime", "closeTime must be after openTime")
+			} else {
+				hours = &dailyHours{open: open, close: closeAt, loc: loc}
+			}
+		}
+	}
+
+	if err := br.err(); err != nil {
+		return nil, err
+	}
+
+	// El calendario también confirma que el cubículo existe.
+	cal, err := s.loadCalendar(ctx, req.CubicleId, window)
+	if err != nil {
+		return nil, err
+	}
+
+	busy, err := s.loadBusy(ctx, req.CubicleId, window)
+	if err != nil {
+		return nil, grpcerr.DB(err, "availability of cubicle "+req.CubicleId)
+	}
+
+	free := timerange.Intersect(timerange.Subtract([]timerange.Interval{window}, busy), openIntervals(cal))
+	if hours != nil {
+		free = timerange.Intersect(free, hours.windows(window))
+	}
+	if slot > 0 {
+		free = snapToSlots(free, slot, loc)
+	}
+
+	resp := &pb.ListFreeIntervalsResponse{}
+	for _, f := range free {
+		resp.Free = append(resp.Free, &pb.TimeInterval{
+			Start: timestamppb.New(f.Start),
+			End:   timestamppb.New(f.End),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"cubiculosup.com/internal/auth"
+	"cubiculosup.com/internal/migrate"
+	"cubiculosup.com/internal/timerange"
+	pb "cubiculosup.com/proto"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// day es la fecha fija de las pruebas puras; at(h, m) es una hora de ese día en UTC.
+var day = time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+func at(h, m int) time.Time {
+	return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
+}
+
+func span(fromH, fromM, toH, toM int) timerange.Interval {
+	return timerange.Interval{Start: at(fromH, fromM), End: at(toH, toM)}
+}
+
+func equalIntervals(a, b []timerange.Interval) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Start.Equal(b[i].Start) || !a[i].End.Equal(b[i].End) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAvailabilityIn(t *testing.T) {
+	window := span(8, 0, 20, 0) // window.Start es "ahora"
+	allDay := []timerange.Interval{window}
+	tests := []struct {
+		name    string
+		busy    []timerange.Interval
+		open    []timerange.Interval
+		wantNow bool
+		want    time.Time // cero: sin hueco en la ventana
+	}{
+		{"empty busy list", nil, allDay, true, at(8, 0)},
+		{"booking only in the future", []timerange.Interval{span(15, 0, 16, 0)}, allDay, true, at(8, 0)},
+		{"back-to-back bookings from now", []timerange.Interval{span(7, 30, 9, 0), span(9, 0, 10, 0), span(10, 0, 10, 30)},
+			allDay, false, at(10, 30)},
+		{"overlapping bookings from now", []timerange.Interval{span(9, 0, 11, 0), span(7, 0, 9, 30), span(10, 45, 11, 15)},
+			allDay, false, at(11, 15)},
+		{"window outside opening hours", nil, []timerange.Interval{span(12, 0, 18, 0)}, false, at(12, 0)},
+		{"first open slot booked", []timerange.Interval{span(12, 0, 13, 0)}, []timerange.Interval{span(12, 0, 18, 0)}, false, at(13, 0)},
+		{"closed the whole window", nil, nil, false, time.Time{}},
+		{"booked while open", []timerange.Interval{span(11, 0, 19, 0)}, []timerange.Interval{span(12, 0, 18, 0)}, false, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := availabilityIn(window, tt.busy, tt.open)
+			if got.AvailableNow != tt.wantNow {
+				t.Errorf("availableNow = %v, want %v", got.AvailableNow, tt.wantNow)
+			}
+			switch {
+			case tt.want.IsZero() && got.NextAvailable != nil:
+				t.Errorf("nextAvailable = %v, want none", got.NextAvailable.AsTime())
+			case !tt.want.IsZero() && (got.NextAvailable == nil || !got.NextAvailable.AsTime().Equal(tt.want)):
+				t.Errorf("nextAvailable = %v, want %v", got.NextAvailable, tt.want)
+			}
+		})
+	}
+}
+
+func TestDailyHoursAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+	// El 8 de marzo de 2026 se adelanta el reloj a las 02:00.
+	window := timerange.Interval{Start: time.Date(2026, 3, 8, 0, 0, 0, 0, loc), End: time.Date(2026, 3, 9, 0, 0, 0, 0, loc)}
+	hours := dailyHours{open: 8 * 60, close: 20 * 60, loc: loc}
+
+	want := []timerange.Interval{{Start: time.Date(2026, 3, 8, 8, 0, 0, 0, loc), End: time.Date(2026, 3, 8, 20, 0, 0, 0, loc)}}
+	if got := hours.windows(window); !equalIntervals(got, want) {
+		t.Errorf("windows = %v, want %v", got, want)
+	}
+
+	free := []timerange.Interval{{Start: time.Date(2026, 3, 8, 9, 10, 0, 0, loc), End: time.Date(2026, 3, 8, 11, 50, 0, 0, loc)}}
+	want = []timerange.Interval{{Start: time.Date(2026, 3, 8, 9, 30, 0, 0, loc), End: time.Date(2026, 3, 8, 11, 30, 0, 0, loc)}}
+	if got := snapToSlots(free, 30*time.Minute, loc); !equalIntervals(got, want) {
+		t.Errorf("snapToSlots = %v, want %v", got, want)
+	}
+}
+
+// testDB abre la base de TEST_DATABASE_URL y le aplica las migraciones. Sin la variable
+// la prueba se salta.
+func testDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	runner, err := migrate.New(db)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := runner.Up(context.Background()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// testCubicle registra un cubículo nuevo y borra su lista de espera, sus reservas y sus
+// series al terminar la prueba.
+func testCubicle(t *testing.T, db *sql.DB) string {
+	t.Helper()
+	id := "test-" + uuid.NewString()
+	if _, err := db.Exec(`INSERT INTO metadata (id, name, location, capacity) VALUES ($1, $1, 'test', 4)`, id); err != nil {
+		t.Fatalf("insert cubicle: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM waitlist_entries WHERE cubicle_id = $1`, id)
+		db.Exec(`DELETE FROM reservations WHERE cubicle_id = $1`, id)
+		db.Exec(`DELETE FROM reservation_series WHERE cubicle_id = $1`, id)
+		db.Exec(`DELETE FROM metadata WHERE id = $1`, id)
+	})
+	return id
+}
+
+// insertReservation guarda una reserva de test-user y regresa su record_id.
+func insertReservation(t *testing.T, db *sql.DB, cubicleID, status string, start, end time.Time) string {
+	t.Helper()
+	recordID := uuid.NewString()
+	if _, err := db.Exec(`
+		INSERT INTO reservations (record_id, cubicle_id, user_id, start_time, end_time, status)
+		VALUES ($1, $2, 'test-user', $3, $4, $5)
+	`, recordID, cubicleID, start, end, status); err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+	return recordID
+}
+
+// alwaysOpen es un MetadataService cuyo calendario está abierto en toda la ventana pedida
+// y donde todo cubículo existe en la ubicación "test".
+type alwaysOpen struct {
+	pb.MetadataServiceClient
+}
+
+func (alwaysOpen) GetMetadata(_ context.Context, req *pb.GetMetadataRequest, _ ...grpc.CallOption) (*pb.GetMetadataResponse, error) {
+	return &pb.GetMetadataResponse{Metadata: &pb.Metadata{Id: req.CubicleId, Name: req.CubicleId, Location: "test", Capacity: 4}}, nil
+}
+
+func (alwaysOpen) GetCalendars(_ context.Context, req *pb.GetCalendarsRequest, _ ...grpc.CallOption) (*pb.GetCalendarsResponse, error) {
+	resp := &pb.GetCalendarsResponse{}
+	for _, id := range req.CubicleIds {
+		resp.Calendars = append(resp.Calendars, &pb.CubicleCalendar{
+			CubicleId: id,
+			Open:      []*pb.TimeInterval{{Start: req.From, End: req.To}},
+		})
+	}
+	return resp, nil
+}
+
+// fakeCalendars es alwaysOpen con ids desconocidos y un contador de llamadas a GetCalendars.
+type fakeCalendars struct {
+	pb.MetadataServiceClient
+	unknown map[string]bool
+	calls   int
+}
+
+func (f *fakeCalendars) GetCalendars(ctx context.Context, req *pb.GetCalendarsRequest, opts ...grpc.CallOption) (*pb.GetCalendarsResponse, error) {
+	f.calls++
+	resp, _ := alwaysOpen{}.GetCalendars(ctx, req, opts...)
+	resp.Calendars = slices.DeleteFunc(resp.Calendars, func(c *pb.CubicleCalendar) bool { return f.unknown[c.CubicleId] })
+	return resp, nil
+}
+
+func TestCheckCalendarsReusesLoadedCalendar(t *testing.T) {
+	// Ocurrencias semanales durante ocho semanas: caben en dos ventanas de 31 días.
+	var occurrences []timerange.Interval
+	for week := 0; week < 8; week++ {
+		occurrences = append(occurrences, span(10+week*7*24, 0, 12+week*7*24, 0))
+	}
+	ctx := context.Background()
+
+	meta := &fakeCalendars{}
+	s := &reservationServer{metaClient: meta}
+	if _, err := s.checkCalendars(ctx, "C-1", occurrences, nil); err != nil {
+		t.Fatalf("checkCalendars: %v", err)
+	}
+	if meta.calls != 2 {
+		t.Errorf("GetCalendars calls without a loaded calendar = %d, want 2", meta.calls)
+	}
+
+	first, err := s.loadCalendar(ctx, "C-1", calendarWindow(occurrences[0].Start))
+	if err != nil {
+		t.Fatalf("loadCalendar: %v", err)
+	}
+	meta.calls = 0
+	violations, err := s.checkCalendars(ctx, "C-1", occurrences, first)
+	if err != nil {
+		t.Fatalf("checkCalendars: %v", err)
+	}
+	if meta.calls != 1 {
+		t.Errorf("GetCalendars calls with a loaded calendar = %d, want 1", meta.calls)
+	}
+	for i, v := range violations {
+		if v != nil {
+			t.Errorf("occurrence %d: %v", i, v)
+		}
+	}
+}
+
+func TestCheckAvailabilityPostgres(t *testing.T) {
+	db := testDB(t)
+	s := &reservationServer{db: db, metaClient: alwaysOpen{}}
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name    string
+		setup   func(cubicleID string)
+		wantNow bool
+		want    time.Time // solo si wantNow es false
+	}{
+		{"no reservations", func(string) {}, true, time.Time{}},
+		{"booking only in the future", func(id string) {
+			insertReservation(t, db, id, statusConfirmed, now.Add(time.Hour), now.Add(2*time.Hour))
+		}, true, time.Time{}},
+		{"back-to-back bookings from now", func(id string) {
+			insertReservation(t, db, id, statusCheckedIn, now.Add(-30*time.Minute), now.Add(30*time.Minute))
+			insertReservation(t, db, id, statusPending, now.Add(30*time.Minute), now.Add(time.Hour))
+			// Las reservas canceladas no ocupan el cubículo.
+			insertReservation(t, db, id, statusCancelled, now.Add(time.Hour), now.Add(2*time.Hour))
+		}, false, now.Add(time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := testCubicle(t, db)
+			tt.setup(id)
+
+			resp, err := s.CheckAvailability(ctx, &pb.CheckAvailabilityRequest{CubicleId: id})
+			if err != nil {
+				t.Fatalf("CheckAvailability: %v", err)
+			}
+			got := resp.Availability
+			if got.AvailableNow != tt.wantNow {
+				t.Errorf("availableNow = %v, want %v", got.AvailableNow, tt.wantNow)
+			}
+			if !tt.wantNow && (got.NextAvailable == nil || !got.NextAvailable.AsTime().Equal(tt.want)) {
+				t.Errorf("nextAvailable = %v, want %v", got.NextAvailable, timestamppb.New(tt.want))
+			}
+		})
+	}
+}
+
+func TestLoadBusyPostgres(t *testing.T) {
+	db := testDB(t)
+	s := &reservationServer{db: db}
+	id := testCubicle(t, db)
+
+	window := timerange.Interval{Start: at(8, 0), End: at(20, 0)}
+	insertReservation(t, db, id, statusConfirmed, at(12, 0), at(13, 0))
+	insertReservation(t, db, id, statusPending, at(7, 0), at(9, 0))     // empieza antes de la ventana
+	insertReservation(t, db, id, statusCancelled, at(14, 0), at(15, 0)) // no ocupa
+	insertReservation(t, db, id, statusConfirmed, at(20, 0), at(21, 0)) // fuera de la ventana
+
+	busy, err := s.loadBusy(context.Background(), id, window)
+	if err != nil {
+		t.Fatalf("loadBusy: %v", err)
+	}
+	want := []timerange.Interval{span(7, 0, 9, 0), span(12, 0, 13, 0)}
+	if !equalIntervals(busy, want) {
+		t.Errorf("loadBusy = %v, want %v", busy, want)
+	}
+}
+
+func TestFindFreeCubiclesPostgres(t *testing.T) {
+	db := testDB(t)
+	s := &reservationServer{db: db}
+	free, overlapsEnd, overlapsStart, cancelled := testCubicle(t, db), testCubicle(t, db), testCubicle(t, db), testCubicle(t, db)
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	end := start.Add(2 * time.Hour)
+	insertReservation(t, db, overlapsEnd, statusConfirmed, start.Add(time.Hour), end.Add(time.Hour))
+	insertReservation(t, db, overlapsStart, statusPending, start.Add(-time.Hour), start.Add(time.Minute))
+	insertReservation(t, db, cancelled, statusCancelled, start, end)
+
+	// insertReservation reserva a nombre de test-user; el estudiante que busca es otro, y
+	// aun así las reservas ajenas deben contar.
+	ctx := auth.NewContext(context.Background(), &auth.Principal{Subject: "someone-else", Roles: []string{auth.RoleStudent}})
+	resp, err := s.FindFreeCubicles(ctx, &pb.FindFreeCubiclesRequest{
+		CubicleIds: []string{free, overlapsEnd, overlapsStart, cancelled, free},
+		From:       timestamppb.New(start),
+		To:         timestamppb.New(end),
+	})
+	if err != nil {
+		t.Fatalf("FindFreeCubicles: %v", err)
+	}
+	want := []string{free, cancelled}
+	if !slices.Equal(resp.CubicleIds, want) {
+		t.Errorf("free cubicles = %v, want %v", resp.CubicleIds, want)
+	}
+}
+
+func TestBatchCheckAvailabilityPostgres(t *testing.T) {
+	db := testDB(t)
+	known, unknown := testCubicle(t, db), "test-"+uuid.NewString()
+	s := &reservationServer{db: db, metaClient: &fakeCalendars{unknown: map[string]bool{unknown: true}}}
+
+	resp, err := s.BatchCheckAvailability(context.Background(), &pb.BatchCheckAvailabilityRequest{
+		CubicleIds: []string{known, unknown, known},
+	})
+	if err != nil {
+		t.Fatalf("BatchCheckAvailability: %v", err)
+	}
+	if len(resp.Availability) != 1 || resp.Availability[0].CubicleId != known || !resp.Availability[0].Availability.AvailableNow {
+		t.Errorf("availability = %v, want %s available now", resp.Availability, known)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Id != unknown || codes.Code(resp.Errors[0].Code) != codes.NotFound {
+		t.Errorf("errors = %v, want NotFound for %s", resp.Errors, unknown)
+	}
+}
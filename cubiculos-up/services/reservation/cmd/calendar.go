@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cubiculosup.com/internal/grpcerr"
+	"cubiculosup.com/internal/timerange"
+	pb "cubiculosup.com/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// availabilityHorizon es hasta dónde buscan CheckAvailability y BatchCheckAvailability el
+// siguiente momento abierto y libre.
+const availabilityHorizon = 14 * 24 * time.Hour
+
+// Razones (ErrorInfo.Reason) de las reservas que no caben en el calendario.
+const (
+	reasonClosed       = "CUBICLE_CLOSED"
+	reasonOutsideHours = "OUTSIDE_OPENING_HOURS"
+)
+
+// loadCalendars pide a MetadataService el calendario de los cubículos dentro de window.
+// Los ids que MetadataService no conoce no aparecen en el resultado.
+func (s *reservationServer) loadCalendars(ctx context.Context, cubicleIDs []string, window timerange.Interval) (map[string]*pb.CubicleCalendar, error) {
+	resp, err := s.metaClient.GetCalendars(ctx, &pb.GetCalendarsRequest{
+		CubicleIds: cubicleIDs,
+		From:       timestamppb.New(window.Start),
+		To:         timestamppb.New(window.End),
+	})
+	if err != nil {
+		return nil, grpcerr.Upstream(err, "metadata")
+	}
+	calendars := make(map[string]*pb.CubicleCalendar, len(resp.Calendars))
+	for _, c := range resp.Calendars {
+		calendars[c.CubicleId] = c
+	}
+	return calendars, nil
+}
+
+// loadCalendar es loadCalendars para un solo cubículo; NotFound si no existe.
+func (s *reservationServer) loadCalendar(ctx context.Context, cubicleID string, window timerange.Interval) (*pb.CubicleCalendar, error) {
+	calendars, err := s.loadCalendars(ctx, []string{cubicleID}, window)
+	if err != nil {
+		return nil, err
+	}
+	cal, ok := calendars[cubicleID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "metadata service: cubicle %s not found", cubicleID)
+	}
+	return cal, nil
+}
+
+// openIntervals regresa los intervalos abiertos del calendario; ya vienen ordenados y sin
+// intervalos contiguos.
+func openIntervals(cal *pb.CubicleCalendar) []timerange.Interval {
+	open := make([]timerange.Interval, len(cal.GetOpen()))
+	for i, o := range cal.GetOpen() {
+		open[i] = timerange.Interval{Start: o.Start.AsTime(), End: o.End.AsTime()}
+	}
+	return open
+}
+
+// calendarViolation construye el FailedPrecondition de una reserva fuera del calendario.
+func calendarViolation(reason, cubicleID, format string, args ...any) error {
+	description := fmt.Sprintf(format, args...)
+	st := status.New(codes.FailedPrecondition, description)
+	if detailed, err := st.WithDetails(
+		&errdetails.ErrorInfo{
+			Reason:   reason,
+			Domain:   policyErrorDomain,
+			Metadata: map[string]string{"cubicleId": cubicleID},
+		},
+		&errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{{
+				Type:        reason,
+				Subject:     "cubicle:" + cubicleID,
+				Description: description,
+			}},
+		},
+	); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// calendarWindow es la ventana de calendario que checkCalendars pide para las ocurrencias
+// que empiezan en start: hasta maxFreeIntervalsRange, el máximo de GetCalendars.
+func calendarWindow(start time.Time) timerange.Interval {
+	return timerange.Interval{Start: start, End: start.Add(maxFreeIntervalsRange)}
+}
+
+// checkCalendars verifica que cada ocurrencia quede completa dentro de un intervalo
+// abierto del cubículo y regresa, por ocurrencia, la violación o nil. occurrences debe
+// venir en orden; los calendarios se piden en ventanas de a lo más maxFreeIntervalsRange.
+// first, si no es nil, es el calendario ya cargado de calendarWindow(occurrences[0].Start)
+// y se usa para la primera ventana en lugar de pedirlo de nuevo.
+func (s *reservationServer) checkCalendars(ctx context.Context, cubicleID string, occurrences []timerange.Interval, first *pb.CubicleCalendar) ([]error, error) {
+	violations := make([]error, len(occurrences))
+	for i := 0; i < len(occurrences); {
+		window := occurrences[i]
+		limit := calendarWindow(window.Start).End
+		j := i + 1
+		for ; j < len(occurrences) && !occurrences[j].End.After(limit); j++ {
+			if occurrences[j].End.After(window.End) {
+				window.End = occurrences[j].End
+			}
+		}
+
+		cal := first
+		if i > 0 || cal == nil {
+			var err error
+			if cal, err = s.loadCalendar(ctx, cubicleID, window); err != nil {
+				return nil, err
+			}
+		}
+		for k := i; k < j; k++ {
+			violations[k] = calendarViolationFor(cal, occurrences[k])
+		}
+		i = j
+	}
+	return violations, nil
+}
+
+// calendarViolationFor regresa nil si r cabe en un intervalo abierto de cal. Si no,
+// reporta el primer cierre que toca r o, si no hay ninguno, que cae fuera del horario.
+func calendarViolationFor(cal *pb.CubicleCalendar, r timerange.Interval) error {
+	for _, o := range openIntervals(cal) {
+		if !o.Start.After(r.Start) && !o.End.Before(r.End) {
+			return nil
+		}
+	}
+
+	for _, c := range cal.Closures {
+		closed := timerange.Interval{Start: c.Start.AsTime(), End: c.End.AsTime()}
+		if !closed.Start.Before(r.End) || !closed.End.After(r.Start) {
+			continue
+		}
+		msg := fmt.Sprintf("cubicle %s is closed from %s to %s", cal.CubicleId,
+			closed.Start.Format(time.RFC3339), closed.End.Format(time.RFC3339))
+		if c.Reason != "" {
+			msg += ": " + c.Reason
+		}
+		return calendarViolation(reasonClosed, cal.CubicleId, "%s", msg)
+	}
+	return calendarViolation(reasonOutsideHours, cal.CubicleId,
+		"reservation is outside the opening hours of %s (time zone %s)", cal.Location, cal.TimeZone)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+
+	"cubiculosup.com/internal/auth"
+	pb "cubiculosup.com/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// methodRoles son los métodos reservados al personal. El resto queda abierto a cualquier
+// usuario autenticado, con las reglas de dueño de abajo.
+var methodRoles = auth.Policy{
+	pb.ReservationService_ConfirmReservation_FullMethodName: {auth.RoleLibrarian, auth.RoleAdmin},
+	pb.ReservationService_MarkNoShow_FullMethodName:         {auth.RoleLibrarian, auth.RoleAdmin},
+}
+
+// privileged indica si p puede actuar sobre reservas ajenas: bibliotecarios,
+// administradores y los otros servicios.
+func privileged(p *auth.Principal) bool {
+	return p.HasAnyRole(auth.RoleLibrarian, auth.RoleAdmin, auth.ServiceRole)
+}
+
+// callerUserID resuelve el userId de una petición con el usuario autenticado: si viene
+// vacío se toma el del token, y si no coincide se rechaza. Sin principal (AUTH_DISABLED)
+// o con un token de servicio se respeta el que mande el cliente.
+func callerUserID(ctx context.Context, userID string) (string, error) {
+	p, ok := auth.FromContext(ctx)
+	if !ok || p.HasRole(auth.ServiceRole) {
+		return userID, nil
+	}
+	if userID == "" {
+		return p.Subject, nil
+	}
+	if userID != p.Subject {
+		return "", status.Errorf(codes.PermissionDenied, "cannot act on behalf of user %s", userID)
+	}
+	return userID, nil
+}
+
+// visibleUserID es el filtro userId de un listado: el personal ve las reservas de todos y
+// un estudiante solo las suyas, como en callerUserID.
+func visibleUserID(ctx context.Context, userID string) (string, error) {
+	if p, ok := auth.FromContext(ctx); ok && privileged(p) {
+		return userID, nil
+	}
+	return callerUserID(ctx, userID)
+}
+
+// checkOwner rechaza con PermissionDenied si el usuario autenticado no es ownerID ni
+// personal. Sin principal (AUTH_DISABLED) no se revisa nada.
+func checkOwner(ctx context.Context, ownerID, what string) error {
+	p, ok := auth.FromContext(ctx)
+	if !ok || privileged(p) || p.Subject == ownerID {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "%s belongs to another user", what)
+}
+
+// ownerGuard es checkOwner como guard de applyTransition.
+func ownerGuard(ctx context.Context) func(current *pb.Reservation) error {
+	return func(current *pb.Reservation) error {
+		return checkOwner(ctx, current.UserId, "reservation "+current.RecordId)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"cubiculosup.com/internal/auth"
+	pb "cubiculosup.com/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// callers son los principales de las pruebas; "none" es una llamada sin autenticar
+// (AUTH_DISABLED).
+var callers = map[string]*auth.Principal{
+	"student":   {Subject: "s1", Roles: []string{auth.RoleStudent}},
+	"librarian": {Subject: "l1", Roles: []string{auth.RoleLibrarian}},
+	"admin":     {Subject: "a1", Roles: []string{auth.RoleAdmin}},
+	"service":   {Subject: "cubicle", Roles: []string{auth.ServiceRole}},
+	"none":      nil,
+}
+
+func callerContext(caller string) context.Context {
+	ctx := context.Background()
+	if p := callers[caller]; p != nil {
+		ctx = auth.NewContext(ctx, p)
+	}
+	return ctx
+}
+
+func TestCallerUserID(t *testing.T) {
+	tests := []struct {
+		caller, userID string
+		want           string
+		code           codes.Code
+	}{
+		{"student", "", "s1", codes.OK},
+		{"student", "s1", "s1", codes.OK},
+		{"student", "s2", "", codes.PermissionDenied},
+		// El personal tampoco reserva a nombre de otro usuario.
+		{"librarian", "", "l1", codes.OK},
+		{"librarian", "s2", "", codes.PermissionDenied},
+		{"admin", "", "a1", codes.OK},
+		{"admin", "s2", "", codes.PermissionDenied},
+		{"service", "s2", "s2", codes.OK},
+		{"service", "", "", codes.OK},
+		{"none", "s2", "s2", codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.caller+"/"+tt.userID, func(t *testing.T) {
+			got, err := callerUserID(callerContext(tt.caller), tt.userID)
+			if status.Code(err) != tt.code || got != tt.want {
+				t.Errorf("callerUserID = %q, %v; want %q, %v", got, err, tt.want, tt.code)
+			}
+		})
+	}
+}
+
+func TestVisibleUserID(t *testing.T) {
+	tests := []struct {
+		caller, userID string
+		want           string
+		code           codes.Code
+	}{
+		{"student", "", "s1", codes.OK},
+		{"student", "s1", "s1", codes.OK},
+		{"student", "s2", "", codes.PermissionDenied},
+		{"librarian", "", "", codes.OK},
+		{"librarian", "s2", "s2", codes.OK},
+		{"admin", "", "", codes.OK},
+		{"admin", "s2", "s2", codes.OK},
+		{"service", "", "", codes.OK},
+		{"service", "s2", "s2", codes.OK},
+		{"none", "", "", codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.caller+"/"+tt.userID, func(t *testing.T) {
+			got, err := visibleUserID(callerContext(tt.caller), tt.userID)
+			if status.Code(err) != tt.code || got != tt.want {
+				t.Errorf("visibleUserID = %q, %v; want %q, %v", got, err, tt.want, tt.code)
+			}
+		})
+	}
+}
+
+func TestCheckOwner(t *testing.T) {
+	tests := []struct {
+		caller, owner string
+		code          codes.Code
+	}{
+		{"student", "s1", codes.OK},
+		{"student", "s2", codes.PermissionDenied},
+		{"librarian", "s2", codes.OK},
+		{"admin", "s2", codes.OK},
+		{"service", "s2", codes.OK},
+		{"none", "s2", codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.caller+"/"+tt.owner, func(t *testing.T) {
+			err := checkOwner(callerContext(tt.caller), tt.owner, "reservation r1")
+			if status.Code(err) != tt.code {
+				t.Errorf("checkOwner = %v, want %v", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestMethodRolesLimitStaffMethods(t *testing.T) {
+	for _, method := range []string{
+		pb.ReservationService_ConfirmReservation_FullMethodName,
+		pb.ReservationService_MarkNoShow_FullMethodName,
+	} {
+		roles := methodRoles[method]
+		for _, caller := range []string{"student", "service"} {
+			if callers[caller].HasAnyRole(roles...) {
+				t.Errorf("%s may call %s", caller, method)
+			}
+		}
+		for _, caller := range []string{"librarian", "admin"} {
+			if !callers[caller].HasAnyRole(roles...) {
+				t.Errorf("%s may not call %s", caller, method)
+			}
+		}
+	}
+}
+
+func TestFindFreeCubiclesOpenToStudents(t *testing.T) {
+	// SearchCubicles llama FindFreeCubicles con el token del estudiante que busca.
+	if roles, limited := methodRoles[pb.ReservationService_FindFreeCubicles_FullMethodName]; limited {
+		t.Errorf("FindFreeCubicles is limited to %v", roles)
+	}
+}
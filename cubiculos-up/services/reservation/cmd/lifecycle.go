@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"cubiculosup.com/internal/grpcerr"
+	pb "cubiculosup.com/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// transition describe un cambio de estado permitido de una reserva.
+type transition struct {
+	to    string
+	from  []string
+	stamp string // columna TIMESTAMPTZ que se marca con now()
+}
+
+var (
+	confirmTransition  = transition{to: statusConfirmed, from: []string{statusPending}, stamp: "confirmed_at"}
+	checkInTransition  = transition{to: statusCheckedIn, from: []string{statusConfirmed}, stamp: "checked_in_at"}
+	completeTransition = transition{to: statusCompleted, from: []string{statusCheckedIn}, stamp: "completed_at"}
+	cancelTransition   = transition{to: statusCancelled, from: []string{statusPending, statusConfirmed}, stamp: "cancelled_at"}
+	noShowTransition   = transition{to: statusNoShow, from: []string{statusConfirmed}, stamp: "cancelled_at"}
+)
+
+func (t transition) allowedFrom(current string) bool {
+	for _, from := range t.from {
+		if from == current {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTransition mueve la reserva recordID al estado t.to si su estado actual lo
+// permite. La fila se bloquea (FOR UPDATE) para que dos réplicas no apliquen transiciones
+// en conflicto. reason solo se guarda si no está vacío (cancel_reason). guard, si no es
+// nil, puede rechazar la transición después de ver la reserva actual.
+func (s *reservationServer) applyTransition(ctx context.Context, recordID string, t transition, reason string, guard func(current *pb.Reservation) error) (*pb.Reservation, error) {
+	if recordID == "" {
+		return nil, status.Error(codes.InvalidArgument, "recordId is required")
+	}
+	what := "reservation " + recordID
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	defer tx.Rollback()
+
+	current, err := scanReservation(tx.QueryRowContext(ctx, `
+		SELECT `+reservationColumns+`
+		FROM reservations
+		WHERE record_id = $1
+		FOR UPDATE
+	`, recordID))
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	if !t.allowedFrom(current.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s is %s and cannot move to %s", what, current.Status, t.to)
+	}
+	if guard != nil {
+		if err := guard(current); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := scanReservation(tx.QueryRowContext(ctx, `
+		UPDATE reservations
+		SET status = $2, `+t.stamp+` = now(), updated_at = now(),
+		    cancel_reason = COALESCE(NULLIF($3, ''), cancel_reason)
+		WHERE record_id = $1
+		RETURNING `+reservationColumns,
+		recordID, t.to, reason))
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	return updated, nil
+}
+
+// CancelReservation marca la reserva como CANCELLED; la fila se conserva para auditoría.
+// Solo el dueño de la reserva o el personal pueden cancelarla.
+func (s *reservationServer) CancelReservation(ctx context.Context, req *pb.CancelReservationRequest) (*pb.CancelReservationResponse, error) {
+	r, err := s.applyTransition(ctx, req.RecordId, cancelTransition, req.Reason, ownerGuard(ctx))
+	if err != nil {
+		return nil, err
+	}
+	s.promoteWaitlistInBackground(ctx, r.CubicleId)
+	return &pb.CancelReservationResponse{Ok: true, Reservation: r}, nil
+}
+
+func (s *reservationServer) ConfirmReservation(ctx context.Context, req *pb.ConfirmReservationRequest) (*pb.ConfirmReservationResponse, error) {
+	r, err := s.applyTransition(ctx, req.RecordId, confirmTransition, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ConfirmReservationResponse{Reservation: r}, nil
+}
+
+func (s *reservationServer) CheckIn(ctx context.Context, req *pb.CheckInRequest) (*pb.CheckInResponse, error) {
+	owner := ownerGuard(ctx)
+	guard := func(current *pb.Reservation) error {
+		if err := owner(current); err != nil {
+			return err
+		}
+		return s.checkInWindow(current, time.Now())
+	}
+	r, err := s.applyTransition(ctx, req.RecordId, checkInTransition, "", guard)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CheckInResponse{Reservation: r}, nil
+}
+
+func (s *reservationServer) CompleteReservation(ctx context.Context, req *pb.CompleteReservationRequest) (*pb.CompleteReservationResponse, error) {
+	r, err := s.applyTransition(ctx, req.RecordId, completeTransition, "", ownerGuard(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CompleteReservationResponse{Reservation: r}, nil
+}
+
+func (s *reservationServer) MarkNoShow(ctx context.Context, req *pb.MarkNoShowRequest) (*pb.MarkNoShowResponse, error) {
+	r, err := s.applyTransition(ctx, req.RecordId, noShowTransition, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	s.promoteWaitlistInBackground(ctx, r.CubicleId)
+	return &pb.MarkNoShowResponse{Reservation: r}, nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// allStatuses son los valores de reservation_status en el orden del ciclo de vida.
+var allStatuses = []string{statusPending, statusConfirmed, statusCheckedIn, statusCompleted, statusCancelled, statusNoShow}
+
+func TestTransitionAllowedFrom(t *testing.T) {
+	tests := []struct {
+		name string
+		t    transition
+		from []string // los únicos estados desde los que se permite
+	}{
+		{"confirm", confirmTransition, []string{statusPending}},
+		{"check-in", checkInTransition, []string{statusConfirmed}},
+		{"complete", completeTransition, []string{statusCheckedIn}},
+		{"cancel", cancelTransition, []string{statusPending, statusConfirmed}},
+		{"no-show", noShowTransition, []string{statusConfirmed}},
+	}
+	for _, tt := range tests {
+		allowed := map[string]bool{}
+		for _, st := range tt.from {
+			allowed[st] = true
+		}
+		for _, current := range allStatuses {
+			if got := tt.t.allowedFrom(current); got != allowed[current] {
+				t.Errorf("%s from %s allowed = %v, want %v", tt.name, current, got, allowed[current])
+			}
+		}
+	}
+}
+
+func TestApplyTransitionPostgres(t *testing.T) {
+	db := testDB(t)
+	s := &reservationServer{db: db}
+	ctx := context.Background()
+	id := testCubicle(t, db)
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name   string
+		status string
+		t      transition
+		want   codes.Code
+	}{
+		{"cancel confirmed", statusConfirmed, cancelTransition, codes.OK},
+		{"cancel pending", statusPending, cancelTransition, codes.OK},
+		{"cancel checked in", statusCheckedIn, cancelTransition, codes.FailedPrecondition},
+		{"cancel cancelled", statusCancelled, cancelTransition, codes.FailedPrecondition},
+		{"confirm confirmed", statusConfirmed, confirmTransition, codes.FailedPrecondition},
+		{"complete checked in", statusCheckedIn, completeTransition, codes.OK},
+		{"no-show checked in", statusCheckedIn, noShowTransition, codes.FailedPrecondition},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Cada caso en su propia hora para no chocar con la restricción de traslape.
+			slot := start.Add(time.Duration(i) * time.Hour)
+			recordID := insertReservation(t, db, id, tt.status, slot, slot.Add(time.Hour))
+
+			r, err := s.applyTransition(ctx, recordID, tt.t, "test", nil)
+			if status.Code(err) != tt.want {
+				t.Fatalf("applyTransition = %v, want %v", err, tt.want)
+			}
+			if err == nil && r.Status != tt.t.to {
+				t.Errorf("status = %s, want %s", r.Status, tt.t.to)
+			}
+		})
+	}
+
+	if _, err := s.applyTransition(ctx, "missing-"+id, cancelTransition, "", nil); status.Code(err) != codes.NotFound {
+		t.Errorf("applyTransition on a missing reservation = %v, want NotFound", err)
+	}
+}
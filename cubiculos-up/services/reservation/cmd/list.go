@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"cubiculosup.com/internal/grpcerr"
+	"cubiculosup.com/internal/pagetoken"
+	pb "cubiculosup.com/proto"
+
+	"github.com/lib/pq"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+// pageToken es el cursor opaco de ListReservations: la llave (start_time, record_id) de la
+// última reserva entregada.
+type pageToken struct {
+	Start    time.Time `json:"s"`
+	RecordID string    `json:"i"`
+}
+
+func (s *reservationServer) ListReservations(ctx context.Context, req *pb.ListReservationsRequest) (*pb.ListReservationsResponse, error) {
+	// Un estudiante solo ve sus reservas; el personal, las de todos.
+	userID, err := visibleUserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	var br badRequest
+
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize < 0:
+		br.add("pageSize", "pageSize must not be negative")
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+
+	for _, st := range req.Status {
+		if !validStatuses[st] {
+			br.add("status", "unknown status %q", st)
+		}
+	}
+
+	if req.From != nil {
+		if err := req.From.CheckValid(); err != nil {
+			br.add("from", "from is not a valid timestamp: %v", err)
+		}
+	}
+	if req.To != nil {
+		if err := req.To.CheckValid(); err != nil {
+			br.add("to", "to is not a valid timestamp: %v", err)
+		}
+	}
+	if req.From != nil && req.To != nil && !req.From.AsTime().Before(req.To.AsTime()) {
+		br.add("to", "to must be after from")
+	}
+
+	// El token queda ligado a los filtros efectivos; el orden de status no cambia el
+	// resultado, así que no debe invalidar el token.
+	statuses := append([]string(nil), req.Status...)
+	sort.Strings(statuses)
+	var from, to time.Time
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+	filters := []any{req.CubicleId, userID, req.SeriesId, statuses, from, to}
+
+	var token *pageToken
+	if req.PageToken != "" {
+		var t pageToken
+		if err := pagetoken.Decode(req.PageToken, &t, filters...); err != nil {
+			br.add("pageToken", "%v", err)
+		} else if t.RecordID == "" {
+			br.add("pageToken", "%v", pagetoken.ErrMalformed)
+		} else {
+			token = &t
+		}
+	}
+
+	if err := br.err(); err != nil {
+		return nil, err
+	}
+
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if req.CubicleId != "" {
+		where = append(where, "cubicle_id = "+arg(req.CubicleId))
+	}
+	if userID != "" {
+		where = append(where, "user_id = "+arg(userID))
+	}
+	if req.SeriesId != "" {
+		where = append(where, "series_id = "+arg(req.SeriesId))
+	}
+	if len(req.Status) > 0 {
+		where = append(where, "status = ANY("+arg(pq.Array(req.Status))+"::reservation_status[])")
+	}
+	// Traslape de [start_time, end_time) con [from, to).
+	if req.From != nil {
+		where = append(where, "end_time > "+arg(from))
+	}
+	if req.To != nil {
+		where = append(where, "start_time < "+arg(to))
+	}
+	if token != nil {
+		where = append(where, fmt.Sprintf("(start_time, record_id) > (%s, %s)", arg(token.Start), arg(token.RecordID)))
+	}
+
+	query := `
+		SELECT ` + reservationColumns + `
+		FROM reservations`
+	if len(where) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(where, " AND ")
+	}
+	query += "\n\t\tORDER BY start_time, record_id\n\t\tLIMIT " + arg(pageSize+1)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, grpcerr.DB(err, "reservation list")
+	}
+	defer rows.Close()
+
+	var list []*pb.Reservation
+	for rows.Next() {
+		r, err := scanReservation(rows)
+		if err != nil {
+			return nil, grpcerr.DB(err, "reservation list")
+		}
+		list = append(list, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, grpcerr.DB(err, "reservation list")
+	}
+
+	resp := &pb.ListReservationsResponse{}
+	if len(list) > pageSize {
+		// Se pidió una fila extra solo para saber si hay otra página.
+		list = list[:pageSize]
+		last := list[len(list)-1]
+		resp.NextPageToken = pagetoken.Encode(pageToken{Start: last.Start.AsTime(), RecordID: last.RecordId}, filters...)
+	}
+	resp.Reservations = list
+
+	return resp, nil
+}
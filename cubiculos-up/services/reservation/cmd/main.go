@@ -3,150 +3,478 @@ package main
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
 	"net"
 	"os"
+	"sync"
 	"time"
 
+	"cubiculosup.com/internal/auth"
+	"cubiculosup.com/internal/config"
+	"cubiculosup.com/internal/graceful"
+	"cubiculosup.com/internal/grpcerr"
+	"cubiculosup.com/internal/healthcheck"
+	"cubiculosup.com/internal/migrate"
+	"cubiculosup.com/internal/timerange"
+	"cubiculosup.com/internal/tlsconfig"
 	pb "cubiculosup.com/proto"
 
-	_ "github.com/lib/pq"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+func init() {
+	// Fuerza gRPC a usar DNS como scheme por defecto (necesario para Kubernetes)
+	resolver.SetDefaultScheme("dns")
+}
+
+// Valores del enum reservation_status de Postgres.
+const (
+	statusPending   = "PENDING"
+	statusConfirmed = "CONFIRMED"
+	statusCheckedIn = "CHECKED_IN"
+	statusCompleted = "COMPLETED"
+	statusCancelled = "CANCELLED"
+	statusNoShow    = "NO_SHOW"
+)
+
+// validStatuses contiene todos los valores de reservation_status.
+var validStatuses = map[string]bool{
+	statusPending:   true,
+	statusConfirmed: true,
+	statusCheckedIn: true,
+	statusCompleted: true,
+	statusCancelled: true,
+	statusNoShow:    true,
+}
+
+// pqExclusionViolation es el SQLSTATE exclusion_violation de Postgres.
+const pqExclusionViolation = "23P01"
+
 type reservationServer struct {
 	pb.UnimplementedReservationServiceServer
-	db *sql.DB
+	db              *sql.DB
+	metaClient      pb.MetadataServiceClient
+	maxSlotDuration time.Duration
+	checkInEarly    time.Duration // cuánto antes de start_time se permite hacer check-in
+	noShowGrace     time.Duration // tras start_time sin check-in la reserva pasa a NO_SHOW
+	offerTTL        time.Duration // vigencia de una oferta de la lista de espera
+	policies        *policyConfig
+	background      sync.WaitGroup // promociones de la lista de espera en curso
 }
 
-func (s *reservationServer) CreateReservation(ctx context.Context, req *pb.CreateReservationRequest) (*pb.CreateReservationResponse, error) {
+// reservationLockNamespace es la primera llave de los advisory locks de Postgres
+// usados para serializar la creación de reservas de un mismo cubículo entre réplicas.
+const reservationLockNamespace = 50052
 
-	r := req.Reservation
-
-	_, err := s.db.Exec(`
-		INSERT INTO reservations (record_id, record_type, user_id, start_time, end_time, status)
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`,
-		r.RecordId,
-		r.RecordType,
-		r.UserId,
-		r.Start.AsTime(),
-		r.End.AsTime(),
-		r.Status,
-	)
+func (s *reservationServer) CreateReservation(ctx context.Context, req *pb.CreateReservationRequest) (*pb.CreateReservationResponse, error) {
+	// El dueño de la reserva es el usuario autenticado.
+	if req.Reservation != nil {
+		userID, err := callerUserID(ctx, req.Reservation.UserId)
+		if err != nil {
+			return nil, err
+		}
+		req.Reservation.UserId = userID
+	}
 
+	meta, err := s.validateReservation(ctx, req.Reservation)
 	if err != nil {
 		return nil, err
 	}
 
-	return &pb.CreateReservationResponse{RecordId: r.RecordId}, nil
-}
+	// El servidor asigna el identificador y el estado; lo que mande el cliente se ignora.
+	r := &pb.Reservation{
+		CubicleId: cubicleIDOf(req.Reservation),
+		UserId:    req.Reservation.UserId,
+		Start:     req.Reservation.Start,
+		End:       req.Reservation.End,
+		Status:    statusConfirmed,
+	}
 
-func (s *reservationServer) CancelReservation(ctx context.Context, req *pb.CancelReservationRequest) (*pb.CancelReservationResponse, error) {
+	// Una reserva individual se trata como una serie de una sola ocurrencia.
+	occurrences := []timerange.Interval{{Start: r.Start.AsTime(), End: r.End.AsTime()}}
+	var (
+		zone string
+		cal  *pb.CubicleCalendar
+	)
+	if req.Recurrence != "" {
+		r.SeriesId = uuid.NewString()
+		if occurrences, zone, cal, err = s.expandRecurrence(ctx, req, r.CubicleId, occurrences[0]); err != nil {
+			return nil, err
+		}
+	}
 
-	res, err := s.db.Exec(`DELETE FROM reservations WHERE record_id = $1`, req.RecordId)
+	// Horario de la ubicación y cierres: se consulta antes de la transacción porque vive en
+	// MetadataService.
+	violations, err := s.checkCalendars(ctx, r.CubicleId, occurrences, cal)
 	if err != nil {
 		return nil, err
 	}
 
-	affected, _ := res.RowsAffected()
-	return &pb.CancelReservationResponse{Ok: affected > 0}, nil
-}
+	what := "reservation for cubicle " + r.CubicleId
 
-func (s *reservationServer) CheckAvailability(ctx context.Context, req *pb.CheckAvailabilityRequest) (*pb.CheckAvailabilityResponse, error) {
-	cubicleID := req.CubicleId
-	log.Printf("Checking availability for cubicle ID: %s", cubicleID)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	defer tx.Rollback()
 
-	// Definir la hora de referencia (ahora)
-	now := time.Now().In(time.UTC)
+	// Toma un lock transaccional por cubículo: dos réplicas que intenten reservar el mismo
+	// cubículo al mismo tiempo se ejecutan en serie, así la verificación de traslape es segura.
+	if err := lockCubicle(ctx, tx, r.CubicleId); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
 
-	// Buscar la reserva activa AHORA, si existe
+	// Cuotas del usuario: el lock por usuario evita que dos reservas simultáneas en
+	// cubículos distintos rebasen juntas el límite.
+	now := time.Now()
+	if err := lockUser(ctx, tx, r.UserId); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	if err := s.checkActive(ctx, tx, r.UserId, meta.Location, now); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	if r.SeriesId != "" {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO reservation_series (id, cubicle_id, user_id, recurrence, time_zone)
+			VALUES ($1, $2, $3, $4, $5)
+		`, r.SeriesId, r.CubicleId, r.UserId, req.Recurrence, zone); err != nil {
+			return nil, grpcerr.DB(err, what)
+		}
+	}
+
+	// Cada ocurrencia se revisa e inserta en orden; los rechazos se acumulan para
+	// reportarlos todos juntos y, si hay alguno, la transacción completa se descarta.
+	resp := &pb.CreateReservationResponse{SeriesId: r.SeriesId}
+	for i, occ := range occurrences {
+		if violations[i] != nil {
+			continue
+		}
+
+		conflict, err := findOverlappingReservation(ctx, tx, r.CubicleId, occ.Start, occ.End)
+		if err != nil {
+			return nil, grpcerr.DB(err, what)
+		}
+		if conflict != nil {
+			violations[i] = conflictError(conflict)
+			continue
+		}
 
-	// Busca una reserva CONFIRMED que haya comenzado (start_time <= now) y que aún no haya terminado (end_time > now).
-	activeRow := s.db.QueryRowContext(ctx, `
-        SELECT end_time
-        FROM reservations
-        WHERE record_type = $1 AND status = 'CONFIRMED' 
-          AND end_time > $2
-        ORDER BY start_time ASC
-        LIMIT 1
-    `, cubicleID, now)
+		if err := s.checkOccurrence(ctx, tx, r.UserId, meta.Location, occ, r.SeriesId != "", now); err != nil {
+			if _, ok := status.FromError(err); !ok {
+				return nil, grpcerr.DB(err, what)
+			}
+			violations[i] = err
+			continue
+		}
 
-	var currentEndTime time.Time
-	err := activeRow.Scan(&currentEndTime)
+		recordID := uuid.NewString()
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO reservations (record_id, cubicle_id, user_id, start_time, end_time, status, confirmed_at, series_id)
+			VALUES ($1, $2, $3, $4, $5, $6, now(), NULLIF($7, ''))
+		`,
+			recordID,
+			r.CubicleId,
+			r.UserId,
+			occ.Start,
+			occ.End,
+			r.Status,
+			r.SeriesId,
+		)
 
-	if err != nil && err != sql.ErrNoRows {
-		log.Printf("SQL Error checking active reservation: %v", err)
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == pqExclusionViolation {
+			// La restricción reservations_no_overlap es la última defensa si algo escapó al lock.
+			return nil, status.Errorf(codes.AlreadyExists, "cubicle %s already reserved in that interval", r.CubicleId)
+		} else if err != nil {
+			return nil, grpcerr.DB(err, what)
+		}
+		resp.RecordIds = append(resp.RecordIds, recordID)
+	}
+
+	if r.SeriesId == "" && violations[0] != nil {
+		return nil, violations[0]
+	}
+	if err := seriesError(occurrences, violations); err != nil {
 		return nil, err
 	}
 
-	// Determinar AvailableNow
-	availableNow := (err == sql.ErrNoRows) // Es true si no se encontró una fila (no hay reservas activas)
+	if err := tx.Commit(); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
 
-	var nextAvailableTime time.Time
+	resp.RecordId = resp.RecordIds[0]
+	return resp, nil
+}
 
-	if !availableNow {
-		// Si hay una reserva activa, la próxima disponibilidad es cuando termine la actual.
-		nextAvailableTime = currentEndTime
+// conflictError construye el AlreadyExists que se regresa cuando una reserva se traslapa
+// con otra; la reserva en conflicto viaja como detalle del status.
+func conflictError(conflict *pb.Reservation) error {
+	st := status.Newf(codes.AlreadyExists, "cubicle %s already reserved from %s to %s (record %s)",
+		conflict.CubicleId,
+		conflict.Start.AsTime().Format(time.RFC3339),
+		conflict.End.AsTime().Format(time.RFC3339),
+		conflict.RecordId,
+	)
+	if detailed, err := st.WithDetails(conflict); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// cubicleIDOf regresa el cubículo de la reserva. Los clientes anteriores a cubicleId
+// mandaban el cubículo en recordType, así que se usa como respaldo.
+func cubicleIDOf(r *pb.Reservation) string {
+	if r.GetCubicleId() != "" {
+		return r.GetCubicleId()
+	}
+	return r.GetRecordType()
+}
 
-	} else {
-		// Si está disponible ahora, busca la PRÓXIMA reserva
+// findOverlappingReservation busca una reserva del cubículo en un estado que lo ocupa
+// (blockingStatuses) cuyo intervalo [start, end) se intersecte con el intervalo dado.
+// Regresa nil si no hay traslape.
+func findOverlappingReservation(ctx context.Context, tx *sql.Tx, cubicleID string, start, end time.Time) (*pb.Reservation, error) {
+	row := tx.QueryRowContext(ctx, `
+		SELECT `+reservationColumns+`
+		FROM reservations
+		WHERE cubicle_id = $1 AND status = ANY($4::reservation_status[])
+		  AND start_time < $3 AND end_time > $2
+		ORDER BY start_time ASC
+		LIMIT 1
+	`, cubicleID, start, end, pq.Array(blockingStatuses))
 
-		// Consulta SQL: Busca la reserva CONFIRMED más próxima en el futuro (start_time > now).
-		nextRow := s.db.QueryRowContext(ctx, `
-            SELECT start_time
-            FROM reservations
-            WHERE record_type = $1 AND status = 'CONFIRMED' AND start_time > $2
-            ORDER BY start_time ASC
-            LIMIT 1
-        `, cubicleID, now)
+	r, err := scanReservation(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return r, err
+}
 
-		err = nextRow.Scan(&nextAvailableTime)
+// reservationColumns son las columnas, en orden, que espera scanReservation.
+const reservationColumns = `record_id, cubicle_id, user_id, start_time, end_time, status,
+		checked_in_at, cancelled_at, cancel_reason, series_id`
 
-		if err != nil && err != sql.ErrNoRows {
-			log.Printf("SQL Error checking next reservation: %v", err)
-			return nil, err
-		}
+// rowScanner lo implementan *sql.Row y *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
 
-		if err == sql.ErrNoRows {
-			// No hay reservas futuras, la disponibilidad es indefinida (usamos una marca de tiempo lejana o la hora actual)
-			// Usamos la hora actual, ya que técnicamente está disponible AHORA.
-			nextAvailableTime = now
-		}
+// scanReservation lee una fila con reservationColumns.
+func scanReservation(row rowScanner) (*pb.Reservation, error) {
+	var (
+		r                        pb.Reservation
+		startTime, endTime       time.Time
+		checkedInAt, cancelledAt sql.NullTime
+		cancelReason, seriesID   sql.NullString
+	)
+	if err := row.Scan(&r.RecordId, &r.CubicleId, &r.UserId, &startTime, &endTime, &r.Status,
+		&checkedInAt, &cancelledAt, &cancelReason, &seriesID); err != nil {
+		return nil, err
+	}
+	r.Start = timestamppb.New(startTime)
+	r.End = timestamppb.New(endTime)
+	if checkedInAt.Valid {
+		r.CheckedInAt = timestamppb.New(checkedInAt.Time)
+	}
+	if cancelledAt.Valid {
+		r.CancelledAt = timestamppb.New(cancelledAt.Time)
+	}
+	r.CancelReason = cancelReason.String
+	r.SeriesId = seriesID.String
+	return &r, nil
+}
+
+func (s *reservationServer) CheckAvailability(ctx context.Context, req *pb.CheckAvailabilityRequest) (*pb.CheckAvailabilityResponse, error) {
+	cubicleID := req.CubicleId
+	log.Printf("Checking availability for cubicle ID: %s", cubicleID)
+
+	// Definir la hora de referencia (ahora)
+	now := time.Now().In(time.UTC)
+
+	// Se busca el primer hueco abierto y libre dentro de availabilityHorizon.
+	window := timerange.Interval{Start: now, End: now.Add(availabilityHorizon)}
+
+	cal, err := s.loadCalendar(ctx, cubicleID, window)
+	if err != nil {
+		return nil, err
+	}
+
+	busy, err := s.loadBusy(ctx, cubicleID, window)
+	if err != nil {
+		log.Printf("SQL Error loading reservations: %v", err)
+		return nil, grpcerr.DB(err, "availability of cubicle "+cubicleID)
 	}
 
 	// Construir la respuesta
 	return &pb.CheckAvailabilityResponse{
-		Availability: &pb.Availability{
-			AvailableNow:  availableNow,
-			NextAvailable: timestamppb.New(nextAvailableTime),
-		},
+		Availability: availabilityIn(window, busy, openIntervals(cal)),
 	}, nil
 }
+
+// callTimeout pone un plazo de d a cada llamada saliente; si el contexto ya trae uno más
+// corto, ese es el que manda.
+func callTimeout(d time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
 func main() {
-	dbURL := os.Getenv("DATABASE_URL")
-	db, err := sql.Open("postgres", dbURL)
+	loader := config.NewLoader("reservation")
+	listen := loader.Listen(50052)
+	dbCfg := loader.DB()
+	healthCfg := loader.Health()
+	shutdownCfg := loader.Shutdown()
+	metaPeer := loader.Peer("metadata", "metadata:50051", 2*time.Second)
+	var (
+		migrateOnStart bool
+		policyFile     string
+		sweepInterval  time.Duration
+		server         = &reservationServer{}
+	)
+	loader.Bool(&migrateOnStart, "migrate-on-start", "MIGRATE_ON_START", true, "apply pending migrations at startup")
+	loader.String(&policyFile, "policy-file", "RESERVATION_POLICY_FILE", "", "JSON booking policy; empty uses the defaults")
+	loader.Duration(&server.maxSlotDuration, "max-slot-duration", "MAX_SLOT_DURATION", defaultMaxSlotDuration, "longest reservation allowed")
+	loader.Duration(&server.checkInEarly, "check-in-early", "CHECK_IN_EARLY", defaultCheckInEarly, "how long before the start check-in opens")
+	loader.Duration(&server.noShowGrace, "no-show-grace", "NO_SHOW_GRACE", defaultNoShowGrace, "how long after the start a reservation without check-in is released")
+	loader.Duration(&server.offerTTL, "waitlist-offer-ttl", "WAITLIST_OFFER_TTL", defaultWaitlistOfferTTL, "how long a waitlist offer stays open")
+	loader.Duration(&sweepInterval, "no-show-sweep-interval", "NO_SHOW_SWEEP_INTERVAL", defaultNoShowSweepInterval, "how often no-shows and waitlist offers are swept")
+	loader.Check(config.Positive("max-slot-duration", &server.maxSlotDuration))
+	loader.Check(config.Positive("check-in-early", &server.checkInEarly))
+	loader.Check(config.Positive("no-show-grace", &server.noShowGrace))
+	loader.Check(config.Positive("waitlist-offer-ttl", &server.offerTTL))
+	loader.Check(config.Positive("no-show-sweep-interval", &sweepInterval))
+	if err := loader.Load(os.Args[1:]); err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
+	// ctx termina con SIGTERM: detiene los trabajos en segundo plano y drena el servidor.
+	ctx := graceful.SignalContext()
+
+	db, err := dbCfg.Open(context.Background())
 	if err != nil {
-		log.Fatalf("cannot connect db: %v", err)
+		log.Fatalf("cannot connect to db: %v", err)
 	}
 
-	if err := db.Ping(); err != nil {
-		log.Fatalf("ping error: %v", err)
+	// "migrate up|down [n]|status" administra el esquema y termina.
+	if args := loader.Args(); len(args) > 0 && args[0] == "migrate" {
+		if err := migrate.RunCommand(context.Background(), db, args[1:], os.Stdout); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
 	}
 
-	lis, err := net.Listen("tcp", ":50052")
+	// Por defecto cada réplica aplica las migraciones pendientes al arrancar;
+	// el advisory lock hace que solo una las ejecute.
+	if migrateOnStart {
+		runner, err := migrate.New(db)
+		if err != nil {
+			log.Fatalf("cannot load migrations: %v", err)
+		}
+		if _, err := runner.Up(context.Background()); err != nil {
+			log.Fatalf("cannot migrate database: %v", err)
+		}
+	}
+
+	lis, err := net.Listen("tcp", listen.Addr())
 	if err != nil {
 		log.Fatalf("error listening: %v", err)
 	}
 
-	s := grpc.NewServer()
-	pb.RegisterReservationServiceServer(s, &reservationServer{db: db})
+	authCfg := auth.ConfigFromEnv()
+	verifier, err := auth.NewVerifier(authCfg)
+	if err != nil {
+		log.Fatalf("cannot configure authentication: %v", err)
+	}
+	if verifier.Disabled() {
+		log.Println("WARNING: authentication is disabled (AUTH_DISABLED=true)")
+	}
+
+	tlsCfg := tlsconfig.FromEnv()
+	serverCreds, err := tlsconfig.ServerCredentials(tlsCfg)
+	if err != nil {
+		log.Fatalf("cannot configure TLS: %v", err)
+	}
+	clientCreds, err := tlsconfig.ClientCredentials(tlsCfg)
+	if err != nil {
+		log.Fatalf("cannot configure TLS: %v", err)
+	}
+	if tlsCfg.Insecure {
+		log.Println("WARNING: serving without TLS (TLS_INSECURE=true)")
+	}
+
+	// Cliente de Metadata para verificar que el cubículo exista antes de reservar.
+	metaConn, err := grpc.NewClient(
+		metaPeer.Target(),
+		grpc.WithTransportCredentials(clientCreds),
+		grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingPolicy":"%s"}`, roundrobin.Name)),
+		grpc.WithChainUnaryInterceptor(
+			callTimeout(metaPeer.Timeout),
+			auth.UnaryClientInterceptor(auth.NewServiceToken(authCfg, "reservation")),
+		),
+	)
+	if err != nil {
+		log.Fatalf("cannot create metadata client: %v", err)
+	}
+
+	server.policies, err = loadPolicyConfig(policyFile)
+	if err != nil {
+		log.Fatalf("cannot load reservation policy: %v", err)
+	}
+
+	server.db = db
+	server.metaClient = pb.NewMetadataServiceClient(metaConn)
+
+	// Libera las reservas sin check-in y atiende las listas de espera; es seguro correrlo
+	// en todas las réplicas.
+	sweeperDone := make(chan struct{})
+	go func() {
+		defer close(sweeperDone)
+		server.runNoShowSweeper(ctx, sweepInterval)
+	}()
+
+	s := grpc.NewServer(
+		grpc.Creds(serverCreds),
+		grpc.ChainUnaryInterceptor(verifier.UnaryServerInterceptor(), methodRoles.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(verifier.StreamServerInterceptor(), methodRoles.StreamServerInterceptor()),
+	)
+	pb.RegisterReservationServiceServer(s, server)
 	reflection.Register(s)
 
-	log.Println("Reservation service running with PostgreSQL on port 50052")
-	if err := s.Serve(lis); err != nil {
+	// Readiness: "" y ReservationService dejan de servir si se pierde Postgres.
+	hs := healthcheck.NewServer(pb.ReservationService_ServiceDesc.ServiceName)
+	healthpb.RegisterHealthServer(s, hs)
+	go healthcheck.Watch(ctx, hs, healthCfg.Interval, []healthcheck.Check{healthcheck.DB(db)}, pb.ReservationService_ServiceDesc.ServiceName)
+	var probes *grpc.Server
+	if healthCfg.Port != 0 {
+		if probes, err = healthcheck.Serve(healthCfg.Addr(), hs); err != nil {
+			log.Fatalf("cannot serve health checks: %v", err)
+		}
+	}
+
+	log.Printf("Reservation service running with PostgreSQL on port %d", listen.Port)
+	if err := graceful.Serve(ctx, s, lis, hs, shutdownCfg.Delay, shutdownCfg.Timeout); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
+
+	// El barrido y las promociones en curso terminan antes de cerrar el pool.
+	<-sweeperDone
+	server.background.Wait()
+	if probes != nil {
+		probes.Stop()
+	}
+	graceful.Close("metadata connection", metaConn)
+	graceful.Close("database", db)
+	log.Println("Reservation service stopped")
 }
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "cubiculosup.com/proto"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCreateReservationOverlapPostgres(t *testing.T) {
+	db := testDB(t)
+	s := &reservationServer{
+		db:              db,
+		metaClient:      alwaysOpen{},
+		maxSlotDuration: defaultMaxSlotDuration,
+		policies:        defaultPolicyConfig(),
+	}
+	// Pasado mañana, dentro del horizonte de la política por defecto.
+	base := time.Now().UTC().Truncate(time.Hour).Add(48 * time.Hour)
+	hour := func(h int) time.Time { return base.Add(time.Duration(h) * time.Hour) }
+
+	tests := []struct {
+		name       string
+		status     string // estado de la reserva existente de 1 a 3
+		start, end int
+		conflict   bool
+	}{
+		{"overlaps the start", statusConfirmed, 0, 2, true},
+		{"overlaps the end", statusConfirmed, 2, 4, true},
+		{"inside", statusConfirmed, 1, 3, true},
+		{"pending also blocks", statusPending, 2, 3, true},
+		{"checked in also blocks", statusCheckedIn, 2, 3, true},
+		{"ends where it starts", statusConfirmed, 0, 1, false},
+		{"starts where it ends", statusConfirmed, 3, 4, false},
+		{"cancelled does not block", statusCancelled, 1, 3, false},
+		{"no-show does not block", statusNoShow, 1, 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := testCubicle(t, db)
+			existing := insertReservation(t, db, id, tt.status, hour(1), hour(3))
+
+			resp, err := s.CreateReservation(context.Background(), &pb.CreateReservationRequest{
+				Reservation: &pb.Reservation{
+					CubicleId: id,
+					UserId:    "test-" + uuid.NewString(),
+					Start:     timestamppb.New(hour(tt.start)),
+					End:       timestamppb.New(hour(tt.end)),
+				},
+			})
+			if !tt.conflict {
+				if err != nil {
+					t.Fatalf("CreateReservation: %v", err)
+				}
+				if resp.RecordId == "" || resp.RecordId == existing {
+					t.Errorf("record id = %q", resp.RecordId)
+				}
+				return
+			}
+
+			st := status.Convert(err)
+			if st.Code() != codes.AlreadyExists {
+				t.Fatalf("CreateReservation = %v, want AlreadyExists", err)
+			}
+			var conflict *pb.Reservation
+			for _, d := range st.Details() {
+				if r, ok := d.(*pb.Reservation); ok {
+					conflict = r
+				}
+			}
+			if conflict == nil {
+				t.Fatalf("%v has no conflicting reservation detail", err)
+			}
+			if conflict.RecordId != existing || conflict.CubicleId != id || conflict.Status != tt.status ||
+				!conflict.Start.AsTime().Equal(hour(1)) || !conflict.End.AsTime().Equal(hour(3)) {
+				t.Errorf("conflict = %v, want record %s from %s to %s", conflict, existing, hour(1), hour(3))
+			}
+
+			var count int
+			if err := db.QueryRow(`SELECT COUNT(*) FROM reservations WHERE cubicle_id = $1`, id).Scan(&count); err != nil {
+				t.Fatal(err)
+			}
+			if count != 1 {
+				t.Errorf("%d reservations after the rejection, want only the existing one", count)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	pb "cubiculosup.com/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultCheckInEarly        = 15 * time.Minute
+	defaultNoShowGrace         = 15 * time.Minute
+	defaultNoShowSweepInterval = time.Minute
+
+	// noShowBatchSize limita las filas que libera cada barrido.
+	noShowBatchSize = 500
+	// noShowReason se guarda en cancel_reason de las reservas liberadas.
+	noShowReason = "no check-in within grace period"
+)
+
+// checkInWindow es el guard de CheckIn: en now solo se permite desde checkInEarly antes
+// de start_time hasta noShowGrace después; pasado ese plazo el barrido la libera.
+func (s *reservationServer) checkInWindow(r *pb.Reservation, now time.Time) error {
+	start := r.Start.AsTime()
+	if opens := start.Add(-s.checkInEarly); now.Before(opens) {
+		return status.Errorf(codes.FailedPrecondition, "check-in for reservation %s opens at %s", r.RecordId, opens.Format(time.RFC3339))
+	}
+	if closes := start.Add(s.noShowGrace); !now.Before(closes) {
+		return status.Errorf(codes.FailedPrecondition, "check-in for reservation %s closed at %s", r.RecordId, closes.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// releaseNoShows pasa a NO_SHOW las reservas CONFIRMED sin check-in cuyo start_time fue
+// hace más de noShowGrace. Es seguro con varias réplicas: las filas se bloquean con
+// FOR UPDATE SKIP LOCKED y el UPDATE vuelve a exigir status = 'CONFIRMED', así que cada
+// reserva la libera una sola réplica. NO_SHOW no ocupa el cubículo, así que el resto del
+// intervalo queda libre de inmediato.
+func (s *reservationServer) releaseNoShows(ctx context.Context) ([]*pb.Reservation, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		UPDATE reservations
+		SET status = 'NO_SHOW', cancelled_at = now(), updated_at = now(), cancel_reason = $3
+		WHERE record_id IN (
+			SELECT record_id
+			FROM reservations
+			WHERE status = 'CONFIRMED' AND start_time <= $1
+			ORDER BY start_time
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		) AND status = 'CONFIRMED'
+		RETURNING `+reservationColumns,
+		time.Now().Add(-s.noShowGrace), noShowBatchSize, noShowReason)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var released []*pb.Reservation
+	for rows.Next() {
+		r, err := scanReservation(rows)
+		if err != nil {
+			return nil, err
+		}
+		released = append(released, r)
+	}
+	return released, rows.Err()
+}
+
+// runNoShowSweeper ejecuta releaseNoShows y el barrido de la lista de espera cada interval
+// hasta que se cancele ctx.
+func (s *reservationServer) runNoShowSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Una vez empezado, el barrido termina aunque llegue SIGTERM: ctx solo detiene el
+		// ciclo, y main espera a que regrese antes de cerrar la base de datos.
+		work := context.WithoutCancel(ctx)
+		released, err := s.releaseNoShows(work)
+		if err != nil {
+			log.Printf("no-show sweep failed: %v", err)
+		}
+		for _, r := range released {
+			log.Printf("Reservation %s for cubicle %s released as NO_SHOW", r.RecordId, r.CubicleId)
+		}
+
+		// Ofrece lo liberado (incluidos los no-shows de arriba) a las listas de espera.
+		s.sweepWaitlist(work)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	pb "cubiculosup.com/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCheckInWindow(t *testing.T) {
+	s := &reservationServer{checkInEarly: 15 * time.Minute, noShowGrace: 10 * time.Minute}
+	start := at(10, 0)
+	r := &pb.Reservation{RecordId: "r1", Start: timestamppb.New(start), End: timestamppb.New(at(12, 0))}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want codes.Code
+	}{
+		{"before the window opens", at(9, 44), codes.FailedPrecondition},
+		{"just before it opens", start.Add(-15*time.Minute - time.Nanosecond), codes.FailedPrecondition},
+		{"when it opens", at(9, 45), codes.OK},
+		{"at the start", start, codes.OK},
+		{"just before the grace ends", start.Add(10*time.Minute - time.Nanosecond), codes.OK},
+		// Desde ese momento el barrido puede liberarla como NO_SHOW.
+		{"when the grace ends", at(10, 10), codes.FailedPrecondition},
+		{"during the reservation", at(11, 0), codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(s.checkInWindow(r, tt.now)); got != tt.want {
+				t.Errorf("checkInWindow at %s = %v, want %v", tt.now.Format(time.TimeOnly), got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"cubiculosup.com/internal/timerange"
+)
+
+// policyErrorDomain es el dominio de los errdetails.ErrorInfo de violaciones de política.
+const policyErrorDomain = "reservation.cubiculosup.com"
+
+// Razones (ErrorInfo.Reason) de las violaciones de política.
+const (
+	reasonMaxActive   = "MAX_ACTIVE_RESERVATIONS"
+	reasonMaxPerDay   = "MAX_HOURS_PER_DAY"
+	reasonMaxPerWeek  = "MAX_HOURS_PER_WEEK"
+	reasonMinLeadTime = "MIN_LEAD_TIME"
+	reasonMaxAdvance  = "MAX_ADVANCE_BOOKING"
+	reasonMaxSeries   = "MAX_SERIES_ADVANCE"
+)
+
+// userLockNamespace es la primera llave del advisory lock por usuario que serializa las
+// reservas de un mismo usuario, para que dos peticiones simultáneas no rebasen su cuota.
+const userLockNamespace = 50053
+
+// duration es un time.Duration que en JSON se escribe como "90m", "4h", etc.
+type duration time.Duration
+
+func (d *duration) UnmarshalJSON(b []byte) error {
+	var raw string
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(raw)
+	if err != nil {
+		return err
+	}
+	*d = duration(parsed)
+	return nil
+}
+
+// policy son los límites de reserva de un usuario. Un campo nil no impone límite; en
+// los overrides por ubicación, nil hereda el valor de la política por defecto.
+// MaxSeriesAdvance es el horizonte de las ocurrencias de una serie recurrente; si es nil
+// se usa MaxAdvance.
+type policy struct {
+	MaxActive        *int      `json:"maxActiveReservations"`
+	MaxPerDay        *duration `json:"maxPerDay"`
+	MaxPerWeek       *duration `json:"maxPerWeek"`
+	MinLeadTime      *duration `json:"minLeadTime"`
+	MaxAdvance       *duration `json:"maxAdvance"`
+	MaxSeriesAdvance *duration `json:"maxSeriesAdvance"`
+}
+
+// merge regresa p con los campos que override sí define.
+func (p policy) merge(override policy) policy {
+	if override.MaxActive != nil {
+		p.MaxActive = override.MaxActive
+	}
+	if override.MaxPerDay != nil {
+		p.MaxPerDay = override.MaxPerDay
+	}
+	if override.MaxPerWeek != nil {
+		p.MaxPerWeek = override.MaxPerWeek
+	}
+	if override.MinLeadTime != nil {
+		p.MinLeadTime = override.MinLeadTime
+	}
+	if override.MaxAdvance != nil {
+		p.MaxAdvance = override.MaxAdvance
+	}
+	if override.MaxSeriesAdvance != nil {
+		p.MaxSeriesAdvance = override.MaxSeriesAdvance
+	}
+	return p
+}
+
+// policyConfig es el contenido de RESERVATION_POLICY_FILE, por ejemplo:
+//
+//	{
+//	  "timeZone": "America/Mexico_City",
+//	  "default": {"maxActiveReservations": 3, "maxPerDay": "4h", "maxPerWeek": "12h",
+//	              "minLeadTime": "0s", "maxAdvance": "336h", "maxSeriesAdvance": "2880h"},
+//	  "locations": {"Biblioteca 2do piso": {"maxPerDay": "2h"}}
+//	}
+type policyConfig struct {
+	TimeZone  string            `json:"timeZone"`
+	Default   policy            `json:"default"`
+	Locations map[string]policy `json:"locations"`
+
+	loc *time.Location
+}
+
+func intPtr(v int) *int                     { return &v }
+func durationPtr(d time.Duration) *duration { v := duration(d); return &v }
+
+// defaultPolicyConfig se usa cuando no hay RESERVATION_POLICY_FILE.
+func defaultPolicyConfig() *policyConfig {
+	return &policyConfig{
+		Default: policy{
+			MaxActive:   intPtr(3),
+			MaxPerDay:   durationPtr(4 * time.Hour),
+			MaxPerWeek:  durationPtr(12 * time.Hour),
+			MinLeadTime: durationPtr(0), // no se reserva en el pasado
+			MaxAdvance:  durationPtr(14 * 24 * time.Hour),
+			// Un semestre, para las series recurrentes de los grupos de estudio.
+			MaxSeriesAdvance: durationPtr(120 * 24 * time.Hour),
+		},
+		loc: time.UTC,
+	}
+}
+
+// loadPolicyConfig lee la política del archivo path (RESERVATION_POLICY_FILE) o regresa
+// la default si path está vacío.
+func loadPolicyConfig(path string) (*policyConfig, error) {
+	if path == "" {
+		return defaultPolicyConfig(), nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg policyConfig
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	cfg.loc = time.UTC
+	if cfg.TimeZone != "" {
+		if cfg.loc, err = time.LoadLocation(cfg.TimeZone); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return &cfg, nil
+}
+
+// forLocation regresa la política efectiva de una ubicación.
+func (c *policyConfig) forLocation(location string) policy {
+	return c.Default.merge(c.Locations[location])
+}
+
+// policyViolation construye el FailedPrecondition de una violación de política. reason
+// es el valor legible por máquina (ErrorInfo.Reason).
+func policyViolation(reason, userID, location, limit, format string, args ...any) error {
+	description := fmt.Sprintf(format, args...)
+	st := status.New(codes.FailedPrecondition, "reservation policy: "+description)
+	if detailed, err := st.WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: reason,
+			Domain: policyErrorDomain,
+			Metadata: map[string]string{
+				"userId":   userID,
+				"location": location,
+				"limit":    limit,
+			},
+		},
+		&errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{{
+				Type:        reason,
+				Subject:     "user:" + userID,
+				Description: description,
+			}},
+		},
+	); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// usedTime suma cuánto tiempo de window ocupan las reservas vigentes o completadas del
+// usuario.
+func usedTime(ctx context.Context, tx *sql.Tx, userID string, window timerange.Interval) (time.Duration, error) {
+	var seconds float64
+	err := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(EXTRACT(EPOCH FROM LEAST(end_time, $3) - GREATEST(start_time, $2))), 0)
+		FROM reservations
+		WHERE user_id = $1 AND status = ANY($4::reservation_status[])
+		  AND start_time < $3 AND end_time > $2
+	`, userID, window.Start, window.End, pq.Array(countedStatuses)).Scan(&seconds)
+	return time.Duration(seconds * float64(time.Second)), err
+}
+
+// countedStatuses son los estados que cuentan para las horas por día/semana.
+var countedStatuses = []string{statusPending, statusConfirmed, statusCheckedIn, statusCompleted}
+
+// overlap regresa cuánto de a cae dentro de b.
+func overlap(a, b timerange.Interval) time.Duration {
+	if c := a.Clip(b); !c.Empty() {
+		return c.End.Sub(c.Start)
+	}
+	return 0
+}
+
+// startOfWeek regresa el lunes a medianoche (hora local) de la semana de t.
+func startOfWeek(t time.Time, loc *time.Location) time.Time {
+	day := localMidnight(t, loc)
+	offset := (int(day.Weekday()) + 6) % 7 // lunes = 0
+	return day.AddDate(0, 0, -offset)
+}
+
+// dayWindow es el día local de t, de medianoche a medianoche; dura 23 o 25 horas los días
+// de cambio de horario.
+func dayWindow(t time.Time, loc *time.Location) timerange.Interval {
+	day := localMidnight(t, loc)
+	return timerange.Interval{Start: day, End: day.AddDate(0, 0, 1)}
+}
+
+// weekWindow es la semana local de t, de lunes a lunes.
+func weekWindow(t time.Time, loc *time.Location) timerange.Interval {
+	week := startOfWeek(t, loc)
+	return timerange.Interval{Start: week, End: week.AddDate(0, 0, 7)}
+}
+
+// checkActive aplica maxActiveReservations a una reserva o serie nueva del usuario; una
+// serie cuenta como una sola reserva activa. Debe llamarse dentro de la transacción de
+// CreateReservation, después de lockUser.
+func (s *reservationServer) checkActive(ctx context.Context, tx *sql.Tx, userID, location string, now time.Time) error {
+	p := s.policies.forLocation(location)
+	if p.MaxActive == nil {
+		return nil
+	}
+
+	var active int
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT COALESCE(series_id, record_id))
+		FROM reservations
+		WHERE user_id = $1 AND status = ANY($2::reservation_status[]) AND end_time > $3
+	`, userID, pq.Array(blockingStatuses), now).Scan(&active); err != nil {
+		return err
+	}
+	if active >= *p.MaxActive {
+		return policyViolation(reasonMaxActive, userID, location, strconv.Itoa(*p.MaxActive),
+			"user %s already has %d active reservations (maximum %d)", userID, active, *p.MaxActive)
+	}
+	return nil
+}
+
+// checkOccurrence aplica la anticipación, el horizonte y las cuotas de horas de la
+// ubicación a r, una reserva individual o una ocurrencia de una serie. Las ocurrencias
+// ya insertadas en tx cuentan para las cuotas de las siguientes.
+func (s *reservationServer) checkOccurrence(ctx context.Context, tx *sql.Tx, userID, location string, r timerange.Interval, series bool, now time.Time) error {
+	p := s.policies.forLocation(location)
+	loc := s.policies.loc
+
+	if p.MinLeadTime != nil {
+		if lead := time.Duration(*p.MinLeadTime); r.Start.Sub(now) < lead {
+			return policyViolation(reasonMinLeadTime, userID, location, lead.String(),
+				"reservations must be made at least %s in advance", lead)
+		}
+	}
+	if series && p.MaxSeriesAdvance != nil {
+		if horizon := time.Duration(*p.MaxSeriesAdvance); r.Start.Sub(now) > horizon {
+			return policyViolation(reasonMaxSeries, userID, location, horizon.String(),
+				"recurring reservations can be made at most %s in advance", horizon)
+		}
+	} else if p.MaxAdvance != nil {
+		if horizon := time.Duration(*p.MaxAdvance); r.Start.Sub(now) > horizon {
+			return policyViolation(reasonMaxAdvance, userID, location, horizon.String(),
+				"reservations can be made at most %s in advance", horizon)
+		}
+	}
+
+	// Horas por día y por semana: se revisa cada día/semana que toca la reserva.
+	type quota struct {
+		limit  *duration
+		reason string
+		unit   string
+		window func(t time.Time, loc *time.Location) timerange.Interval
+	}
+	quotas := []quota{
+		{p.MaxPerDay, reasonMaxPerDay, "day", dayWindow},
+		{p.MaxPerWeek, reasonMaxPerWeek, "week", weekWindow},
+	}
+	for _, q := range quotas {
+		if q.limit == nil {
+			continue
+		}
+		limit := time.Duration(*q.limit)
+		for w := q.window(r.Start, loc); w.Start.Before(r.End); w = q.window(w.End, loc) {
+			used, err := usedTime(ctx, tx, userID, w)
+			if err != nil {
+				return err
+			}
+			if total := used + overlap(r, w); total > limit {
+				return policyViolation(q.reason, userID, location, limit.String(),
+					"user %s would have %s reserved in the %s starting %s (maximum %s)",
+					userID, total, q.unit, w.Start.Format("2006-01-02"), limit)
+			}
+		}
+	}
+
+	return nil
+}
+
+// lockUser serializa, entre réplicas, las reservas del usuario hasta el fin de tx.
+func lockUser(ctx context.Context, tx *sql.Tx, userID string) error {
+	_, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2))`, userLockNamespace, userID)
+	return err
+}
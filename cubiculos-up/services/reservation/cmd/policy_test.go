@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cubiculosup.com/internal/timerange"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// policyReason regresa el ErrorInfo.Reason de una violación de política, o "" si err no
+// es un FailedPrecondition con ese detalle.
+func policyReason(t *testing.T, err error) string {
+	t.Helper()
+	st := status.Convert(err)
+	if err == nil || st.Code() != codes.FailedPrecondition {
+		return ""
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.Domain == policyErrorDomain {
+			return info.Reason
+		}
+	}
+	t.Errorf("%v has no ErrorInfo in %s", err, policyErrorDomain)
+	return ""
+}
+
+func TestLoadPolicyConfig(t *testing.T) {
+	cfg, err := loadPolicyConfig("")
+	if err != nil {
+		t.Fatalf("loadPolicyConfig without a file: %v", err)
+	}
+	if p := cfg.forLocation("anywhere"); *p.MaxActive != 3 || time.Duration(*p.MaxPerDay) != 4*time.Hour || cfg.loc != time.UTC {
+		t.Errorf("default policy = %+v in %v", p, cfg.loc)
+	}
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	cfg, err = loadPolicyConfig(write("policy.json", `{
+		"timeZone": "America/Mexico_City",
+		"default": {"maxActiveReservations": 3, "maxPerDay": "4h", "maxPerWeek": "12h", "maxAdvance": "336h"},
+		"locations": {"Biblioteca 2do piso": {"maxPerDay": "2h", "minLeadTime": "30m"}}
+	}`))
+	if err != nil {
+		t.Fatalf("loadPolicyConfig: %v", err)
+	}
+	if cfg.loc.String() != "America/Mexico_City" {
+		t.Errorf("time zone = %v", cfg.loc)
+	}
+
+	// El override cambia solo los campos que define; el resto se hereda del default.
+	p := cfg.forLocation("Biblioteca 2do piso")
+	if time.Duration(*p.MaxPerDay) != 2*time.Hour || time.Duration(*p.MinLeadTime) != 30*time.Minute {
+		t.Errorf("override = maxPerDay %v, minLeadTime %v; want 2h, 30m", *p.MaxPerDay, *p.MinLeadTime)
+	}
+	if *p.MaxActive != 3 || time.Duration(*p.MaxPerWeek) != 12*time.Hour || time.Duration(*p.MaxAdvance) != 336*time.Hour {
+		t.Errorf("inherited fields = %+v", p)
+	}
+	if p.MaxSeriesAdvance != nil {
+		t.Errorf("maxSeriesAdvance = %v, want no limit", *p.MaxSeriesAdvance)
+	}
+	// Sin override, la política por defecto; el default no cambia por el merge.
+	if p := cfg.forLocation("Sala 1"); time.Duration(*p.MaxPerDay) != 4*time.Hour || p.MinLeadTime != nil {
+		t.Errorf("policy without override = %+v", p)
+	}
+
+	for name, content := range map[string]string{
+		"syntax.json":   `{"default": `,
+		"duration.json": `{"default": {"maxPerDay": "four hours"}}`,
+		"zone.json":     `{"timeZone": "Mars/Olympus_Mons"}`,
+	} {
+		if _, err := loadPolicyConfig(write(name, content)); err == nil {
+			t.Errorf("loadPolicyConfig(%s) succeeded", name)
+		}
+	}
+	if _, err := loadPolicyConfig(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("loadPolicyConfig of a missing file succeeded")
+	}
+}
+
+func TestQuotaWindows(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+	local := func(m time.Month, d, h int) time.Time { return time.Date(2026, m, d, h, 0, 0, 0, loc) }
+
+	tests := []struct {
+		name   string
+		window func(time.Time, *time.Location) timerange.Interval
+		t      time.Time
+		want   timerange.Interval
+	}{
+		{"day", dayWindow, local(3, 4, 15), timerange.Interval{Start: local(3, 4, 0), End: local(3, 5, 0)}},
+		// 03:30 UTC del 5 de marzo todavía es el 4 en Nueva York.
+		{"day in local time", dayWindow, time.Date(2026, 3, 5, 3, 30, 0, 0, time.UTC), timerange.Interval{Start: local(3, 4, 0), End: local(3, 5, 0)}},
+		{"day when clocks go forward", dayWindow, local(3, 8, 12), timerange.Interval{Start: local(3, 8, 0), End: local(3, 9, 0)}},
+		{"week from monday", weekWindow, local(3, 2, 0), timerange.Interval{Start: local(3, 2, 0), End: local(3, 9, 0)}},
+		// Domingo en la noche local, ya lunes en UTC: sigue siendo la semana que empezó el 2.
+		{"week ends on sunday night", weekWindow, time.Date(2026, 3, 9, 3, 30, 0, 0, time.UTC), timerange.Interval{Start: local(3, 2, 0), End: local(3, 9, 0)}},
+		{"week in november", weekWindow, local(11, 1, 20), timerange.Interval{Start: local(10, 26, 0), End: local(11, 2, 0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.window(tt.t, loc)
+			if !got.Start.Equal(tt.want.Start) || !got.End.Equal(tt.want.End) {
+				t.Errorf("window(%s) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+
+	if d := dayWindow(local(3, 8, 12), loc); d.End.Sub(d.Start) != 23*time.Hour {
+		t.Errorf("the day clocks go forward lasts %s, want 23h", d.End.Sub(d.Start))
+	}
+	if w := weekWindow(local(11, 1, 12), loc); w.End.Sub(w.Start) != 7*24*time.Hour+time.Hour {
+		t.Errorf("the week clocks go back lasts %s, want 169h", w.End.Sub(w.Start))
+	}
+}
+
+func TestCheckOccurrenceLeadTimeAndHorizon(t *testing.T) {
+	// Sin cuotas de horas checkOccurrence no consulta la base, así que tx puede ser nil.
+	s := &reservationServer{policies: &policyConfig{
+		Default: policy{
+			MinLeadTime:      durationPtr(0),
+			MaxAdvance:       durationPtr(14 * 24 * time.Hour),
+			MaxSeriesAdvance: durationPtr(120 * 24 * time.Hour),
+		},
+		Locations: map[string]policy{"strict": {MinLeadTime: durationPtr(time.Hour)}},
+		loc:       time.UTC,
+	}}
+	noSeriesHorizon := &policyConfig{Default: policy{MaxAdvance: durationPtr(7 * 24 * time.Hour)}, loc: time.UTC}
+
+	now := at(9, 0)
+	in := func(d time.Duration) timerange.Interval {
+		return timerange.Interval{Start: now.Add(d), End: now.Add(d + time.Hour)}
+	}
+	const day = 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		policies *policyConfig
+		location string
+		r        timerange.Interval
+		series   bool
+		want     string // ErrorInfo.Reason; vacío si se permite
+	}{
+		{"in the past", nil, "", in(-time.Minute), false, reasonMinLeadTime},
+		{"right now", nil, "", in(0), false, ""},
+		{"within the location lead time", nil, "strict", in(30 * time.Minute), false, reasonMinLeadTime},
+		{"after the location lead time", nil, "strict", in(time.Hour), false, ""},
+		{"at the advance horizon", nil, "", in(14 * day), false, ""},
+		{"beyond the advance horizon", nil, "", in(14*day + time.Minute), false, reasonMaxAdvance},
+		{"series beyond maxAdvance", nil, "", in(60 * day), true, ""},
+		{"series beyond its horizon", nil, "", in(121 * day), true, reasonMaxSeries},
+		{"series without a series horizon", noSeriesHorizon, "", in(8 * day), true, reasonMaxAdvance},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := s
+			if tt.policies != nil {
+				srv = &reservationServer{policies: tt.policies}
+			}
+			err := srv.checkOccurrence(context.Background(), nil, "u1", tt.location, tt.r, tt.series, now)
+			if got := policyReason(t, err); got != tt.want || (tt.want == "" && err != nil) {
+				t.Errorf("checkOccurrence = %v, want reason %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// insertUserReservation es insertReservation a nombre de userID.
+func insertUserReservation(t *testing.T, db *sql.DB, cubicleID, userID, status string, start, end time.Time) {
+	t.Helper()
+	if _, err := db.Exec(`
+		INSERT INTO reservations (record_id, cubicle_id, user_id, start_time, end_time, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, uuid.NewString(), cubicleID, userID, start, end, status); err != nil {
+		t.Fatalf("insert reservation: %v", err)
+	}
+}
+
+// policyTx abre una transacción que se descarta al terminar la prueba.
+func policyTx(t *testing.T, db *sql.DB) *sql.Tx {
+	t.Helper()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	t.Cleanup(func() { tx.Rollback() })
+	return tx
+}
+
+func TestUsedTimePostgres(t *testing.T) {
+	db := testDB(t)
+	id, other := testCubicle(t, db), testCubicle(t, db)
+	user := "test-" + uuid.NewString()
+
+	insertUserReservation(t, db, id, user, statusConfirmed, at(9, 0), at(11, 0))
+	insertUserReservation(t, db, other, user, statusCompleted, at(13, 0), at(14, 0))
+	insertUserReservation(t, db, id, user, statusCancelled, at(15, 0), at(16, 0)) // no cuenta
+	insertUserReservation(t, db, id, user, statusNoShow, at(16, 0), at(17, 0))    // no cuenta
+	insertUserReservation(t, db, id, user, statusPending, at(23, 0), at(25, 0))   // la mitad cae en el día siguiente
+	insertUserReservation(t, db, id, "someone-else", statusConfirmed, at(18, 0), at(19, 0))
+
+	used, err := usedTime(context.Background(), policyTx(t, db), user, dayWindow(at(12, 0), time.UTC))
+	if err != nil {
+		t.Fatalf("usedTime: %v", err)
+	}
+	if want := 4 * time.Hour; used != want {
+		t.Errorf("usedTime = %s, want %s", used, want)
+	}
+}
+
+func TestCheckQuotasPostgres(t *testing.T) {
+	db := testDB(t)
+	id := testCubicle(t, db)
+	user := "test-" + uuid.NewString()
+	s := &reservationServer{policies: &policyConfig{
+		Default: policy{
+			MaxActive:  intPtr(2),
+			MaxPerDay:  durationPtr(4 * time.Hour),
+			MaxPerWeek: durationPtr(6 * time.Hour),
+		},
+		loc: time.UTC,
+	}}
+	ctx := context.Background()
+	now := at(0, 0) // lunes a medianoche
+
+	// Tres horas el lunes, en una sola reserva.
+	insertUserReservation(t, db, id, user, statusConfirmed, at(9, 0), at(12, 0))
+
+	tests := []struct {
+		name string
+		r    timerange.Interval
+		want string
+	}{
+		{"fills the day", span(14, 0, 15, 0), ""},
+		{"over the day", span(14, 0, 16, 0), reasonMaxPerDay},
+		{"next day", span(24+9, 0, 24+12, 0), ""},
+		{"over the week", span(48+9, 0, 48+13, 0), reasonMaxPerWeek},
+		// La semana siguiente empieza de cero aunque la reserva empiece el domingo.
+		{"spans into next week", span(6*24+23, 0, 7*24+2, 0), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.checkOccurrence(ctx, policyTx(t, db), user, "test", tt.r, false, now)
+			if got := policyReason(t, err); got != tt.want || (tt.want == "" && err != nil) {
+				t.Errorf("checkOccurrence = %v, want reason %q", err, tt.want)
+			}
+		})
+	}
+
+	// maxActiveReservations: una serie cuenta como una sola reserva activa.
+	seriesID := uuid.NewString()
+	if _, err := db.Exec(`
+		INSERT INTO reservation_series (id, cubicle_id, user_id, recurrence, time_zone)
+		VALUES ($1, $2, $3, 'FREQ=DAILY;COUNT=2', 'UTC')
+	`, seriesID, id, user); err != nil {
+		t.Fatalf("insert series: %v", err)
+	}
+	for _, d := range []int{2, 3} {
+		if _, err := db.Exec(`
+			INSERT INTO reservations (record_id, cubicle_id, user_id, start_time, end_time, status, series_id)
+			VALUES ($1, $2, $3, $4, $5, 'CONFIRMED', $6)
+		`, uuid.NewString(), id, user, at(d*24+9, 0), at(d*24+10, 0), seriesID); err != nil {
+			t.Fatalf("insert occurrence: %v", err)
+		}
+	}
+	if got := policyReason(t, s.checkActive(ctx, policyTx(t, db), user, "test", now)); got != reasonMaxActive {
+		t.Errorf("checkActive with a reservation and a series = %q, want %q", got, reasonMaxActive)
+	}
+	// Lo que ya terminó no está activo.
+	if err := s.checkActive(ctx, policyTx(t, db), user, "test", at(5*24, 0)); err != nil {
+		t.Errorf("checkActive after everything ended = %v", err)
+	}
+}
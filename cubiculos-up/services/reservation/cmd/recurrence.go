@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"cubiculosup.com/internal/timerange"
+)
+
+// maxOccurrences limita las ocurrencias de una serie.
+const maxOccurrences = 100
+
+// rruleDays mapea los días de BYDAY a time.Weekday.
+var rruleDays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// recurrence es el subconjunto soportado de un RRULE de RFC 5545.
+type recurrence struct {
+	freq     string // "DAILY" o "WEEKLY"
+	interval int
+	count    int       // 0 si la serie termina con UNTIL
+	until    time.Time // cero si la serie termina con COUNT; inclusivo
+	byDay    map[time.Weekday]bool
+	loc      *time.Location
+}
+
+// parseRecurrence interpreta reglas como "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;COUNT=8";
+// acepta el prefijo "RRULE:". Se exige COUNT o UNTIL para que la serie sea finita. Las
+// fechas y horas locales de la regla se interpretan en loc.
+func parseRecurrence(rule string, loc *time.Location) (*recurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	r := &recurrence{interval: 1, loc: loc}
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(key)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("expected KEY=VALUE, got %q", part)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("%s appears more than once", key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			r.freq = strings.ToUpper(value)
+			if r.freq != "DAILY" && r.freq != "WEEKLY" {
+				return nil, fmt.Errorf("FREQ must be DAILY or WEEKLY, got %q", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("INTERVAL must be a positive integer, got %q", value)
+			}
+			r.interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("COUNT must be a positive integer, got %q", value)
+			}
+			r.count = n
+		case "UNTIL":
+			until, err := parseUntil(value, loc)
+			if err != nil {
+				return nil, err
+			}
+			r.until = until
+		case "BYDAY":
+			r.byDay = map[time.Weekday]bool{}
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleDays[strings.ToUpper(day)]
+				if !ok {
+					return nil, fmt.Errorf("BYDAY expects SU, MO, TU, WE, TH, FR or SA, got %q", day)
+				}
+				r.byDay[weekday] = true
+			}
+		case "WKST":
+			// Las semanas siempre empiezan en lunes, que es el default de RFC 5545.
+			if strings.ToUpper(value) != "MO" {
+				return nil, fmt.Errorf("only WKST=MO is supported")
+			}
+		default:
+			return nil, fmt.Errorf("%s is not supported", key)
+		}
+	}
+
+	switch {
+	case r.freq == "":
+		return nil, fmt.Errorf("FREQ is required")
+	case r.count == 0 && r.until.IsZero():
+		return nil, fmt.Errorf("COUNT or UNTIL is required")
+	case r.count > 0 && !r.until.IsZero():
+		return nil, fmt.Errorf("COUNT and UNTIL must not be used together")
+	case r.count > maxOccurrences:
+		return nil, fmt.Errorf("COUNT must be at most %d", maxOccurrences)
+	}
+	return r, nil
+}
+
+// parseUntil acepta UNTIL en UTC ("20270601T120000Z"), en hora local de loc
+// ("20270601T120000") o como fecha ("20270601", que incluye todo ese día).
+func parseUntil(value string, loc *time.Location) (time.Time, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, nil
+	}
+	if until, err := time.ParseInLocation("20060102T150405", value, loc); err == nil {
+		return until, nil
+	}
+	if day, err := time.ParseInLocation("20060102", value, loc); err == nil {
+		return day.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return time.Time{}, fmt.Errorf("UNTIL must look like 20270601, 20270601T120000 or 20270601T120000Z, got %q", value)
+}
+
+// expand regresa las ocurrencias de la serie cuya primera ocurrencia es first. Cada
+// ocurrencia conserva la hora local de inicio y fin de first, así que una reserva de
+// 10:00 a 12:00 sigue siéndolo después de un cambio de horario.
+func (r *recurrence) expand(first timerange.Interval) ([]timerange.Interval, error) {
+	loc := r.loc
+	start, end := first.Start.In(loc), first.End.In(loc)
+	if r.byDay != nil && !r.byDay[start.Weekday()] {
+		return nil, fmt.Errorf("the first occurrence is on %s, which BYDAY does not include", start.Weekday())
+	}
+
+	y, m, d := start.Date()
+	endDays := int(time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC).
+		Sub(time.Date(y, m, d, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+
+	at := func(y int, m time.Month, d int) timerange.Interval {
+		return timerange.Interval{
+			Start: time.Date(y, m, d, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), loc),
+			End:   time.Date(y, m, d+endDays, end.Hour(), end.Minute(), end.Second(), end.Nanosecond(), loc),
+		}
+	}
+
+	// days son los desplazamientos, dentro de cada periodo, de los días que se reservan.
+	step := r.interval
+	days := []int{0}
+	if r.freq == "WEEKLY" {
+		step = 7 * r.interval
+		startOffset := (int(start.Weekday()) + 6) % 7 // días desde el lunes de la primera semana
+		d -= startOffset
+		days = nil
+		for weekday := range r.byDay {
+			days = append(days, (int(weekday)+6)%7)
+		}
+		if len(days) == 0 {
+			days = []int{startOffset}
+		}
+		sort.Ints(days)
+	}
+
+	var out []timerange.Interval
+	for period := 0; ; period++ {
+		for _, offset := range days {
+			occ := at(y, m, d+period*step+offset)
+			if occ.Start.Before(first.Start) {
+				continue
+			}
+			// Con FREQ=DAILY, BYDAY filtra los días.
+			if r.freq == "DAILY" && r.byDay != nil && !r.byDay[occ.Start.Weekday()] {
+				continue
+			}
+			if !r.until.IsZero() && occ.Start.After(r.until) {
+				return out, nil
+			}
+			out = append(out, occ)
+			if r.count > 0 && len(out) == r.count {
+				return out, nil
+			}
+			if len(out) > maxOccurrences {
+				return nil, fmt.Errorf("recurrence expands to more than %d occurrences", maxOccurrences)
+			}
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"cubiculosup.com/internal/auth"
+	"cubiculosup.com/internal/timerange"
+	pb "cubiculosup.com/proto"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		rule    string
+		wantErr string // vacío: la regla es válida
+	}{
+		{"FREQ=DAILY;COUNT=3", ""},
+		{"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;COUNT=8", ""},
+		{"freq=weekly;byday=mo;count=2", ""},
+		{"FREQ=WEEKLY;UNTIL=20260401;WKST=MO", ""},
+		{"FREQ=DAILY;UNTIL=20260401T120000Z", ""},
+		{"FREQ=DAILY;COUNT=100", ""},
+
+		{"COUNT=3", "FREQ is required"},
+		{"FREQ=MONTHLY;COUNT=3", "FREQ must be DAILY or WEEKLY"},
+		{"FREQ=DAILY", "COUNT or UNTIL is required"},
+		{"FREQ=DAILY;COUNT=3;UNTIL=20260401", "must not be used together"},
+		{"FREQ=DAILY;COUNT=0", "COUNT must be a positive integer"},
+		{"FREQ=DAILY;COUNT=101", "COUNT must be at most 100"},
+		{"FREQ=DAILY;INTERVAL=0;COUNT=3", "INTERVAL must be a positive integer"},
+		{"FREQ=WEEKLY;BYDAY=MO,XX;COUNT=3", "BYDAY expects"},
+		{"FREQ=WEEKLY;WKST=SU;COUNT=3", "only WKST=MO"},
+		{"FREQ=DAILY;UNTIL=2026-04-01", "UNTIL must look like"},
+		{"FREQ=DAILY;FREQ=WEEKLY;COUNT=3", "FREQ appears more than once"},
+		{"FREQ=DAILY;BYMONTH=3;COUNT=3", "BYMONTH is not supported"},
+		{"FREQ=DAILY;COUNT", "expected KEY=VALUE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			_, err := parseRecurrence(tt.rule, time.UTC)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("parseRecurrence: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("parseRecurrence error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// dates regresa los inicios de occurrences como "2006-01-02 15:04" en su zona.
+func dates(occurrences []timerange.Interval) []string {
+	out := make([]string, len(occurrences))
+	for i, o := range occurrences {
+		out[i] = o.Start.Format("2006-01-02 15:04")
+	}
+	return out
+}
+
+func TestExpand(t *testing.T) {
+	// day es lunes 2 de marzo de 2026; las ocurrencias son de 10:00 a 12:00.
+	first := span(10, 0, 12, 0)
+	tests := []struct {
+		name    string
+		rule    string
+		first   timerange.Interval
+		want    []string
+		wantErr string
+	}{
+		{"daily", "FREQ=DAILY;COUNT=3", first,
+			[]string{"2026-03-02 10:00", "2026-03-03 10:00", "2026-03-04 10:00"}, ""},
+		{"every other day", "FREQ=DAILY;INTERVAL=2;COUNT=3", first,
+			[]string{"2026-03-02 10:00", "2026-03-04 10:00", "2026-03-06 10:00"}, ""},
+		{"daily on weekdays listed in BYDAY", "FREQ=DAILY;BYDAY=MO,WE,FR;COUNT=4", first,
+			[]string{"2026-03-02 10:00", "2026-03-04 10:00", "2026-03-06 10:00", "2026-03-09 10:00"}, ""},
+		{"weekly", "FREQ=WEEKLY;COUNT=3", first,
+			[]string{"2026-03-02 10:00", "2026-03-09 10:00", "2026-03-16 10:00"}, ""},
+		{"every other week on two days", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=4", first,
+			[]string{"2026-03-02 10:00", "2026-03-05 10:00", "2026-03-16 10:00", "2026-03-19 10:00"}, ""},
+		// El lunes de la primera semana ya pasó: la serie sigue en el siguiente lunes.
+		{"weekly starting mid-week", "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=3", span(3*24+10, 0, 3*24+12, 0),
+			[]string{"2026-03-05 10:00", "2026-03-09 10:00", "2026-03-12 10:00"}, ""},
+		{"UNTIL date includes the whole day", "FREQ=DAILY;UNTIL=20260304", first,
+			[]string{"2026-03-02 10:00", "2026-03-03 10:00", "2026-03-04 10:00"}, ""},
+		{"UNTIL time is inclusive", "FREQ=DAILY;UNTIL=20260303T100000Z", first,
+			[]string{"2026-03-02 10:00", "2026-03-03 10:00"}, ""},
+		{"UNTIL before the first occurrence", "FREQ=DAILY;UNTIL=20260301", first, []string{}, ""},
+		{"overnight occurrence", "FREQ=DAILY;COUNT=2", span(22, 0, 25, 0),
+			[]string{"2026-03-02 22:00", "2026-03-03 22:00"}, ""},
+		{"first occurrence outside BYDAY", "FREQ=WEEKLY;BYDAY=TU;COUNT=2", first, nil, "BYDAY does not include"},
+		{"more than maxOccurrences", "FREQ=DAILY;UNTIL=20270101", first, nil, "more than 100 occurrences"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRecurrence(tt.rule, time.UTC)
+			if err != nil {
+				t.Fatalf("parseRecurrence: %v", err)
+			}
+			got, err := rule.expand(tt.first)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expand error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expand: %v", err)
+			}
+			if !slices.Equal(dates(got), tt.want) {
+				t.Errorf("expand = %v, want %v", dates(got), tt.want)
+			}
+			duration := tt.first.End.Sub(tt.first.Start)
+			for _, o := range got {
+				if o.End.Sub(o.Start) != duration {
+					t.Errorf("occurrence %v lasts %s, want %s", o, o.End.Sub(o.Start), duration)
+				}
+			}
+		})
+	}
+}
+
+func TestExpandKeepsWallClockAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+	// El 8 de marzo de 2026 se adelanta el reloj: la tercera ocurrencia ya es EDT.
+	first := timerange.Interval{Start: time.Date(2026, 3, 2, 10, 0, 0, 0, loc), End: time.Date(2026, 3, 2, 12, 0, 0, 0, loc)}
+	rule, err := parseRecurrence("FREQ=DAILY;INTERVAL=3;COUNT=3", loc)
+	if err != nil {
+		t.Fatalf("parseRecurrence: %v", err)
+	}
+	got, err := rule.expand(first)
+	if err != nil {
+		t.Fatalf("expand: %v", err)
+	}
+
+	if want := []string{"2026-03-02 10:00", "2026-03-05 10:00", "2026-03-08 10:00"}; !slices.Equal(dates(got), want) {
+		t.Errorf("expand = %v, want %v", dates(got), want)
+	}
+	if utc := got[2].Start.UTC(); utc.Hour() != 14 {
+		t.Errorf("third occurrence starts at %s UTC, want 14:00 (10:00 EDT)", utc.Format(time.TimeOnly))
+	}
+	if d := got[2].Start.Sub(got[1].Start); d != 71*time.Hour {
+		t.Errorf("gap across the DST change = %s, want 71h", d)
+	}
+}
+
+func TestSeriesError(t *testing.T) {
+	occurrences := []timerange.Interval{span(10, 0, 12, 0), span(24+10, 0, 24+12, 0), span(48+10, 0, 48+12, 0)}
+	if err := seriesError(occurrences, make([]error, len(occurrences))); err != nil {
+		t.Fatalf("seriesError without violations = %v, want nil", err)
+	}
+
+	conflict := &pb.Reservation{RecordId: "r-9", CubicleId: "C-1", Start: timestamppb.New(occurrences[1].Start), End: timestamppb.New(occurrences[1].End)}
+	violations := []error{
+		nil,
+		conflictError(conflict),
+		calendarViolation(reasonClosed, "C-1", "cubicle C-1 is closed"),
+	}
+	st := status.Convert(seriesError(occurrences, violations))
+	if st.Code() != codes.AlreadyExists {
+		t.Errorf("code = %v, want the first rejection's AlreadyExists", st.Code())
+	}
+	if !strings.HasPrefix(st.Message(), "2 of 3 occurrences cannot be reserved") {
+		t.Errorf("message = %q", st.Message())
+	}
+
+	var got []*pb.OccurrenceError
+	for _, d := range st.Details() {
+		if occErr, ok := d.(*pb.OccurrenceError); ok {
+			got = append(got, occErr)
+		}
+	}
+	if len(got) != 2 {
+		t.Fatalf("details = %v, want two OccurrenceError", st.Details())
+	}
+	if got[0].Index != 1 || codes.Code(got[0].Code) != codes.AlreadyExists || got[0].ConflictingRecordId != "r-9" {
+		t.Errorf("first detail = %v, want index 1 AlreadyExists conflicting with r-9", got[0])
+	}
+	if got[1].Index != 2 || codes.Code(got[1].Code) != codes.FailedPrecondition || got[1].ConflictingRecordId != "" {
+		t.Errorf("second detail = %v, want index 2 FailedPrecondition", got[1])
+	}
+	if !got[1].Occurrence.Start.AsTime().Equal(occurrences[2].Start) {
+		t.Errorf("second detail occurrence = %v, want %v", got[1].Occurrence, occurrences[2])
+	}
+}
+
+func TestCancelSeriesPostgres(t *testing.T) {
+	db := testDB(t)
+	s := &reservationServer{db: db, metaClient: alwaysOpen{}}
+	id := testCubicle(t, db)
+
+	seriesID := uuid.NewString()
+	if _, err := db.Exec(`
+		INSERT INTO reservation_series (id, cubicle_id, user_id, recurrence, time_zone)
+		VALUES ($1, $2, 'test-user', 'FREQ=DAILY;COUNT=4', 'UTC')
+	`, seriesID, id); err != nil {
+		t.Fatalf("insert series: %v", err)
+	}
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	var recordIDs []string
+	for i, st := range []string{statusCheckedIn, statusConfirmed, statusPending, statusConfirmed} {
+		slot := start.Add(time.Duration(i) * 24 * time.Hour)
+		recordID := insertReservation(t, db, id, st, slot, slot.Add(time.Hour))
+		if _, err := db.Exec(`UPDATE reservations SET series_id = $2 WHERE record_id = $1`, recordID, seriesID); err != nil {
+			t.Fatalf("attach reservation to series: %v", err)
+		}
+		recordIDs = append(recordIDs, recordID)
+	}
+
+	// Otro estudiante no puede cancelar la serie de test-user.
+	other := auth.NewContext(context.Background(), &auth.Principal{Subject: "someone-else", Roles: []string{auth.RoleStudent}})
+	if _, err := s.CancelSeries(other, &pb.CancelSeriesRequest{SeriesId: seriesID}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("CancelSeries by another student = %v, want PermissionDenied", err)
+	}
+
+	ctx := context.Background()
+	cancelled := func(resp *pb.CancelSeriesResponse) []string {
+		var ids []string
+		for _, r := range resp.Cancelled {
+			if r.Status != statusCancelled {
+				t.Errorf("reservation %s is %s after CancelSeries", r.RecordId, r.Status)
+			}
+			ids = append(ids, r.RecordId)
+		}
+		slices.Sort(ids)
+		return ids
+	}
+
+	// Con from solo se cancelan las ocurrencias que empiezan desde ese momento.
+	resp, err := s.CancelSeries(ctx, &pb.CancelSeriesRequest{SeriesId: seriesID, From: timestamppb.New(start.Add(3 * 24 * time.Hour))})
+	if err != nil {
+		t.Fatalf("CancelSeries from the fourth occurrence: %v", err)
+	}
+	if got, want := cancelled(resp), []string{recordIDs[3]}; !slices.Equal(got, want) {
+		t.Errorf("cancelled = %v, want %v", got, want)
+	}
+
+	// Sin from se cancelan las pendientes; la que ya tiene check-in no se toca.
+	resp, err = s.CancelSeries(ctx, &pb.CancelSeriesRequest{SeriesId: seriesID, Reason: "semester over"})
+	if err != nil {
+		t.Fatalf("CancelSeries: %v", err)
+	}
+	want := []string{recordIDs[1], recordIDs[2]}
+	slices.Sort(want)
+	if got := cancelled(resp); !slices.Equal(got, want) {
+		t.Errorf("cancelled = %v, want %v", got, want)
+	}
+
+	// Una segunda cancelación no encuentra nada pendiente.
+	if resp, err = s.CancelSeries(ctx, &pb.CancelSeriesRequest{SeriesId: seriesID}); err != nil || len(resp.Cancelled) != 0 {
+		t.Errorf("repeated CancelSeries = %v, %v; want nothing cancelled", resp, err)
+	}
+	if _, err := s.CancelSeries(ctx, &pb.CancelSeriesRequest{SeriesId: uuid.NewString()}); status.Code(err) != codes.NotFound {
+		t.Errorf("CancelSeries of a missing series = %v, want NotFound", err)
+	}
+
+	// La cancelación ofrece el cubículo a la lista de espera en segundo plano; se espera a
+	// que termine antes de borrar las filas.
+	s.background.Wait()
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"cubiculosup.com/internal/grpcerr"
+	"cubiculosup.com/internal/timerange"
+	pb "cubiculosup.com/proto"
+
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// expandRecurrence expande la regla de req a partir de la primera ocurrencia first y
+// regresa las ocurrencias junto con la zona horaria usada. Sin timeZone en la petición se
+// usa la del horario de la ubicación del cubículo; en ese caso también se regresa el
+// calendario cargado, que cubre calendarWindow(first.Start), para que checkCalendars no
+// vuelva a pedirlo.
+func (s *reservationServer) expandRecurrence(ctx context.Context, req *pb.CreateReservationRequest, cubicleID string, first timerange.Interval) ([]timerange.Interval, string, *pb.CubicleCalendar, error) {
+	var (
+		br  badRequest
+		cal *pb.CubicleCalendar
+	)
+
+	zone := req.TimeZone
+	if zone == "" {
+		var err error
+		if cal, err = s.loadCalendar(ctx, cubicleID, calendarWindow(first.Start)); err != nil {
+			return nil, "", nil, err
+		}
+		zone = cal.TimeZone
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		br.add("timeZone", "unknown time zone %q", zone)
+		return nil, "", nil, br.err()
+	}
+
+	rule, err := parseRecurrence(req.Recurrence, loc)
+	if err != nil {
+		br.add("recurrence", "%v", err)
+		return nil, "", nil, br.err()
+	}
+	occurrences, err := rule.expand(first)
+	if err != nil {
+		br.add("recurrence", "%v", err)
+		return nil, "", nil, br.err()
+	}
+	if len(occurrences) == 0 {
+		br.add("recurrence", "UNTIL is before the first occurrence")
+		return nil, "", nil, br.err()
+	}
+	return occurrences, zone, cal, nil
+}
+
+// seriesError reúne en un solo status las ocurrencias rechazadas de una serie, con un
+// OccurrenceError por cada una; nil si no hay rechazos. El código es el del primer rechazo.
+func seriesError(occurrences []timerange.Interval, violations []error) error {
+	var (
+		details []protoadapt.MessageV1
+		first   *status.Status
+	)
+	for i, v := range violations {
+		if v == nil {
+			continue
+		}
+		st := status.Convert(v)
+		if first == nil {
+			first = st
+		}
+		occErr := &pb.OccurrenceError{
+			Index: int32(i),
+			Occurrence: &pb.TimeInterval{
+				Start: timestamppb.New(occurrences[i].Start),
+				End:   timestamppb.New(occurrences[i].End),
+			},
+			Code:    int32(st.Code()),
+			Message: st.Message(),
+		}
+		for _, d := range st.Details() {
+			if conflict, ok := d.(*pb.Reservation); ok {
+				occErr.ConflictingRecordId = conflict.RecordId
+			}
+		}
+		details = append(details, occErr)
+	}
+	if first == nil {
+		return nil
+	}
+
+	st := status.Newf(first.Code(), "%d of %d occurrences cannot be reserved; first: %s",
+		len(details), len(occurrences), first.Message())
+	if detailed, err := st.WithDetails(details...); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// CancelSeries cancela de una vez las ocurrencias pendientes de una serie; las que ya
+// empezaron o terminaron (CHECKED_IN, COMPLETED, ...) no se tocan.
+func (s *reservationServer) CancelSeries(ctx context.Context, req *pb.CancelSeriesRequest) (*pb.CancelSeriesResponse, error) {
+	if req.SeriesId == "" {
+		return nil, status.Error(codes.InvalidArgument, "seriesId is required")
+	}
+	var from time.Time
+	if req.From != nil {
+		if err := req.From.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "from is not a valid timestamp: %v", err)
+		}
+		from = req.From.AsTime()
+	}
+	what := "reservation series " + req.SeriesId
+
+	var owner string
+	if err := s.db.QueryRowContext(ctx, `SELECT user_id FROM reservation_series WHERE id = $1`, req.SeriesId).Scan(&owner); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	if err := checkOwner(ctx, owner, what); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		UPDATE reservations
+		SET status = $2, cancelled_at = now(), updated_at = now(),
+		    cancel_reason = COALESCE(NULLIF($3, ''), cancel_reason)
+		WHERE series_id = $1 AND status = ANY($4::reservation_status[]) AND start_time >= $5
+		RETURNING `+reservationColumns,
+		req.SeriesId, cancelTransition.to, req.Reason, pq.Array(cancelTransition.from), from)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	defer rows.Close()
+
+	resp := &pb.CancelSeriesResponse{}
+	for rows.Next() {
+		r, err := scanReservation(rows)
+		if err != nil {
+			return nil, grpcerr.DB(err, what)
+		}
+		resp.Cancelled = append(resp.Cancelled, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	// Sin ocurrencias pendientes la serie ya estaba cancelada o terminada.
+	if len(resp.Cancelled) == 0 {
+		return resp, nil
+	}
+
+	// Todas las ocurrencias de una serie son del mismo cubículo.
+	s.promoteWaitlistInBackground(ctx, resp.Cancelled[0].CubicleId)
+	return resp, nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cubiculosup.com/internal/grpcerr"
+	pb "cubiculosup.com/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultMaxSlotDuration es la duración máxima de una reserva si no se configura MAX_SLOT_DURATION.
+const defaultMaxSlotDuration = 4 * time.Hour
+
+// badRequest acumula las violaciones por campo de una petición.
+type badRequest struct {
+	violations []*errdetails.BadRequest_FieldViolation
+}
+
+func (b *badRequest) add(field, format string, args ...any) {
+	b.violations = append(b.violations, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// err regresa un InvalidArgument con el detalle errdetails.BadRequest, o nil si no hay violaciones.
+func (b *badRequest) err() error {
+	if len(b.violations) == 0 {
+		return nil
+	}
+	st := status.New(codes.InvalidArgument, "invalid request: "+b.violations[0].Description)
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: b.violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// validateReservation revisa la reserva recibida en CreateReservation y regresa la
+// metadata del cubículo. Los campos recordId y status los asigna el servidor, así que no
+// se validan aquí.
+func (s *reservationServer) validateReservation(ctx context.Context, r *pb.Reservation) (*pb.Metadata, error) {
+	var br badRequest
+
+	if r == nil {
+		br.add("reservation", "reservation is required")
+		return nil, br.err()
+	}
+
+	cubicleID := cubicleIDOf(r)
+	if cubicleID == "" {
+		br.add("reservation.cubicleId", "cubicleId is required")
+	}
+	if r.UserId == "" {
+		br.add("reservation.userId", "userId is required")
+	}
+
+	startOK, endOK := true, true
+	if r.Start == nil {
+		br.add("reservation.start", "start is required")
+		startOK = false
+	} else if err := r.Start.CheckValid(); err != nil {
+		br.add("reservation.start", "start is not a valid timestamp: %v", err)
+		startOK = false
+	}
+	if r.End == nil {
+		br.add("reservation.end", "end is required")
+		endOK = false
+	} else if err := r.End.CheckValid(); err != nil {
+		br.add("reservation.end", "end is not a valid timestamp: %v", err)
+		endOK = false
+	}
+
+	if startOK && endOK {
+		start, end := r.Start.AsTime(), r.End.AsTime()
+		if !start.Before(end) {
+			br.add("reservation.end", "end must be after start")
+		} else if d := end.Sub(start); d > s.maxSlotDuration {
+			br.add("reservation.end", "reservation lasts %s, maximum is %s", d, s.maxSlotDuration)
+		}
+	}
+
+	if err := br.err(); err != nil {
+		return nil, err
+	}
+
+	// Solo después de validar la forma consultamos a MetadataService.
+	meta, err := s.metaClient.GetMetadata(ctx, &pb.GetMetadataRequest{CubicleId: cubicleID})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			br.add("reservation.cubicleId", "cubicle %s does not exist", cubicleID)
+			return nil, br.err()
+		}
+		return nil, grpcerr.Upstream(err, "metadata")
+	}
+	if meta.GetMetadata().GetArchived() {
+		return nil, status.Errorf(codes.FailedPrecondition, "cubicle %s is archived and cannot be reserved", cubicleID)
+	}
+
+	return meta.Metadata, nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	pb "cubiculosup.com/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeMetadata es alwaysOpen con cubículos que no existen y cubículos archivados.
+type fakeMetadata struct {
+	alwaysOpen
+	unknown  map[string]bool
+	archived map[string]bool
+}
+
+func (f fakeMetadata) GetMetadata(ctx context.Context, req *pb.GetMetadataRequest, opts ...grpc.CallOption) (*pb.GetMetadataResponse, error) {
+	if f.unknown[req.CubicleId] {
+		return nil, status.Errorf(codes.NotFound, "cubicle %s not found", req.CubicleId)
+	}
+	resp, _ := f.alwaysOpen.GetMetadata(ctx, req, opts...)
+	resp.Metadata.Archived = f.archived[req.CubicleId]
+	return resp, nil
+}
+
+// fieldViolations regresa los campos del detalle errdetails.BadRequest de err.
+func fieldViolations(err error) []string {
+	var fields []string
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				fields = append(fields, v.Field)
+			}
+		}
+	}
+	return fields
+}
+
+func TestCreateReservationValidation(t *testing.T) {
+	// Todos los casos se rechazan antes de abrir la transacción, así que no hace falta db.
+	s := &reservationServer{
+		metaClient: fakeMetadata{
+			unknown:  map[string]bool{"C-gone": true},
+			archived: map[string]bool{"C-old": true},
+		},
+		maxSlotDuration: defaultMaxSlotDuration,
+		policies:        defaultPolicyConfig(),
+	}
+	ts := timestamppb.New
+
+	tests := []struct {
+		name   string
+		r      *pb.Reservation
+		code   codes.Code
+		fields []string
+	}{
+		{"no reservation", nil, codes.InvalidArgument, []string{"reservation"}},
+		{"empty reservation", &pb.Reservation{}, codes.InvalidArgument,
+			[]string{"reservation.cubicleId", "reservation.userId", "reservation.start", "reservation.end"}},
+		{"missing cubicle", &pb.Reservation{UserId: "u1", Start: ts(at(10, 0)), End: ts(at(11, 0))}, codes.InvalidArgument,
+			[]string{"reservation.cubicleId"}},
+		{"missing user", &pb.Reservation{CubicleId: "C-1", Start: ts(at(10, 0)), End: ts(at(11, 0))}, codes.InvalidArgument,
+			[]string{"reservation.userId"}},
+		{"invalid start", &pb.Reservation{CubicleId: "C-1", UserId: "u1", Start: &timestamppb.Timestamp{Nanos: -1}, End: ts(at(11, 0))}, codes.InvalidArgument,
+			[]string{"reservation.start"}},
+		{"end equal to start", &pb.Reservation{CubicleId: "C-1", UserId: "u1", Start: ts(at(10, 0)), End: ts(at(10, 0))}, codes.InvalidArgument,
+			[]string{"reservation.end"}},
+		{"end before start", &pb.Reservation{CubicleId: "C-1", UserId: "u1", Start: ts(at(11, 0)), End: ts(at(10, 0))}, codes.InvalidArgument,
+			[]string{"reservation.end"}},
+		{"over the maximum slot", &pb.Reservation{CubicleId: "C-1", UserId: "u1", Start: ts(at(10, 0)), End: ts(at(10, 0).Add(defaultMaxSlotDuration + time.Minute))}, codes.InvalidArgument,
+			[]string{"reservation.end"}},
+		{"unknown cubicle", &pb.Reservation{CubicleId: "C-gone", UserId: "u1", Start: ts(at(10, 0)), End: ts(at(11, 0))}, codes.InvalidArgument,
+			[]string{"reservation.cubicleId"}},
+		{"archived cubicle", &pb.Reservation{CubicleId: "C-old", UserId: "u1", Start: ts(at(10, 0)), End: ts(at(11, 0))}, codes.FailedPrecondition, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.CreateReservation(context.Background(), &pb.CreateReservationRequest{Reservation: tt.r})
+			if got := status.Code(err); got != tt.code {
+				t.Fatalf("CreateReservation = %v, want %v", err, tt.code)
+			}
+			if got := fieldViolations(err); !slices.Equal(got, tt.fields) {
+				t.Errorf("field violations = %v, want %v", got, tt.fields)
+			}
+		})
+	}
+}
+
+func TestValidateReservationAcceptsMaximumSlot(t *testing.T) {
+	s := &reservationServer{metaClient: fakeMetadata{}, maxSlotDuration: defaultMaxSlotDuration}
+	// Los clientes viejos mandan el cubículo en recordType.
+	meta, err := s.validateReservation(context.Background(), &pb.Reservation{
+		RecordType: "C-1",
+		UserId:     "u1",
+		Start:      timestamppb.New(at(10, 0)),
+		End:        timestamppb.New(at(10, 0).Add(defaultMaxSlotDuration)),
+	})
+	if err != nil {
+		t.Fatalf("validateReservation: %v", err)
+	}
+	if meta.Id != "C-1" || meta.Location != "test" {
+		t.Errorf("metadata = %v, want cubicle C-1 in test", meta)
+	}
+}
@@ -0,0 +1,662 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	"cubiculosup.com/internal/grpcerr"
+	"cubiculosup.com/internal/pagetoken"
+	"cubiculosup.com/internal/timerange"
+	pb "cubiculosup.com/proto"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Valores del enum waitlist_status de Postgres.
+const (
+	waitlistWaiting  = "WAITING"
+	waitlistOffered  = "OFFERED"
+	waitlistAccepted = "ACCEPTED"
+	waitlistExpired  = "EXPIRED"
+	waitlistLeft     = "LEFT"
+)
+
+var validWaitlistStatuses = map[string]bool{
+	waitlistWaiting:  true,
+	waitlistOffered:  true,
+	waitlistAccepted: true,
+	waitlistExpired:  true,
+	waitlistLeft:     true,
+}
+
+const (
+	defaultWaitlistOfferTTL = 15 * time.Minute
+
+	// maxWaitlistCandidates acota las entradas que revisa cada promoción de un cubículo.
+	maxWaitlistCandidates = 100
+	// waitlistSweepBatch limita las ofertas vencidas y cubículos que atiende cada barrido.
+	waitlistSweepBatch = 100
+
+	// Se guardan en cancel_reason de la reserva ofrecida.
+	offerExpiredReason = "waitlist offer expired"
+	offerLeftReason    = "waitlist offer declined"
+)
+
+// waitlistPageToken es el cursor opaco de ListWaitlist: la llave (created_at, id) de la
+// última entrada entregada.
+type waitlistPageToken struct {
+	Created time.Time `json:"c"`
+	ID      string    `json:"i"`
+}
+
+// waitlistColumns son las columnas, en orden, que espera scanWaitlistEntry.
+const waitlistColumns = `id, cubicle_id, user_id, start_time, end_time, status, created_at,
+		offer_record_id, offer_expires_at`
+
+func scanWaitlistEntry(row rowScanner) (*pb.WaitlistEntry, error) {
+	var (
+		e                           pb.WaitlistEntry
+		startTime, endTime, created time.Time
+		offerRecordID               sql.NullString
+		offerExpiresAt              sql.NullTime
+	)
+	if err := row.Scan(&e.Id, &e.CubicleId, &e.UserId, &startTime, &endTime, &e.Status, &created,
+		&offerRecordID, &offerExpiresAt); err != nil {
+		return nil, err
+	}
+	e.Start = timestamppb.New(startTime)
+	e.End = timestamppb.New(endTime)
+	e.CreatedAt = timestamppb.New(created)
+	e.OfferRecordId = offerRecordID.String
+	if offerExpiresAt.Valid {
+		e.OfferExpiresAt = timestamppb.New(offerExpiresAt.Time)
+	}
+	return &e, nil
+}
+
+// lockCubicle toma el mismo advisory lock que CreateReservation, así las promociones de
+// la lista de espera y las reservas nuevas del cubículo se ejecutan en serie.
+func lockCubicle(ctx context.Context, tx *sql.Tx, cubicleID string) error {
+	_, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2))`, reservationLockNamespace, cubicleID)
+	return err
+}
+
+func (s *reservationServer) JoinWaitlist(ctx context.Context, req *pb.JoinWaitlistRequest) (*pb.JoinWaitlistResponse, error) {
+	userID, err := callerUserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = userID
+
+	// Se valida igual que una reserva: mismo intervalo máximo, cubículo existente y no archivado.
+	candidate := &pb.Reservation{CubicleId: req.CubicleId, UserId: req.UserId, Start: req.Start, End: req.End}
+	if _, err = s.validateReservation(ctx, candidate); err != nil {
+		return nil, err
+	}
+	window := timerange.Interval{Start: req.Start.AsTime(), End: req.End.AsTime()}
+	if !window.Start.After(time.Now()) {
+		return nil, status.Error(codes.FailedPrecondition, "cannot join the waitlist for an interval that already started")
+	}
+
+	violations, err := s.checkCalendars(ctx, req.CubicleId, []timerange.Interval{window}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if violations[0] != nil {
+		return nil, violations[0]
+	}
+
+	what := "waitlist of cubicle " + req.CubicleId
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	defer tx.Rollback()
+
+	// Con el lock del cubículo el intervalo no puede liberarse entre la revisión y el INSERT.
+	if err := lockCubicle(ctx, tx, req.CubicleId); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	conflict, err := findOverlappingReservation(ctx, tx, req.CubicleId, window.Start, window.End)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	if conflict == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "cubicle %s is free in that interval; create the reservation instead", req.CubicleId)
+	}
+
+	var duplicate bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM waitlist_entries
+			WHERE cubicle_id = $1 AND user_id = $2 AND status IN ('WAITING', 'OFFERED')
+			  AND start_time < $4 AND end_time > $3
+		)
+	`, req.CubicleId, req.UserId, window.Start, window.End).Scan(&duplicate); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	if duplicate {
+		return nil, status.Errorf(codes.AlreadyExists, "user %s is already waiting for cubicle %s in that interval", req.UserId, req.CubicleId)
+	}
+
+	entry, err := scanWaitlistEntry(tx.QueryRowContext(ctx, `
+		INSERT INTO waitlist_entries (id, cubicle_id, user_id, start_time, end_time)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+waitlistColumns,
+		uuid.NewString(), req.CubicleId, req.UserId, window.Start, window.End))
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	return &pb.JoinWaitlistResponse{Entry: entry}, nil
+}
+
+func (s *reservationServer) LeaveWaitlist(ctx context.Context, req *pb.LeaveWaitlistRequest) (*pb.LeaveWaitlistResponse, error) {
+	if req.EntryId == "" {
+		return nil, status.Error(codes.InvalidArgument, "entryId is required")
+	}
+	what := "waitlist entry " + req.EntryId
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	defer tx.Rollback()
+
+	current, err := scanWaitlistEntry(tx.QueryRowContext(ctx, `
+		SELECT `+waitlistColumns+`
+		FROM waitlist_entries
+		WHERE id = $1
+		FOR UPDATE
+	`, req.EntryId))
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	if err := checkOwner(ctx, current.UserId, what); err != nil {
+		return nil, err
+	}
+	if current.Status != waitlistWaiting && current.Status != waitlistOffered {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s is %s and cannot be left", what, current.Status)
+	}
+
+	// Rechazar una oferta libera la reserva PENDING que la respaldaba.
+	if current.Status == waitlistOffered {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE reservations
+			SET status = 'CANCELLED', cancelled_at = now(), updated_at = now(), cancel_reason = $2
+			WHERE record_id = $1 AND status = 'PENDING'
+		`, current.OfferRecordId, offerLeftReason); err != nil {
+			return nil, grpcerr.DB(err, what)
+		}
+	}
+
+	entry, err := scanWaitlistEntry(tx.QueryRowContext(ctx, `
+		UPDATE waitlist_entries
+		SET status = 'LEFT', updated_at = now()
+		WHERE id = $1
+		RETURNING `+waitlistColumns,
+		req.EntryId))
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	if current.Status == waitlistOffered {
+		s.promoteWaitlistInBackground(ctx, current.CubicleId)
+	}
+	return &pb.LeaveWaitlistResponse{Entry: entry}, nil
+}
+
+func (s *reservationServer) AcceptWaitlistOffer(ctx context.Context, req *pb.AcceptWaitlistOfferRequest) (*pb.AcceptWaitlistOfferResponse, error) {
+	if req.EntryId == "" {
+		return nil, status.Error(codes.InvalidArgument, "entryId is required")
+	}
+	what := "waitlist entry " + req.EntryId
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	defer tx.Rollback()
+
+	current, err := scanWaitlistEntry(tx.QueryRowContext(ctx, `
+		SELECT `+waitlistColumns+`
+		FROM waitlist_entries
+		WHERE id = $1
+		FOR UPDATE
+	`, req.EntryId))
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	if err := checkOwner(ctx, current.UserId, what); err != nil {
+		return nil, err
+	}
+	if current.Status != waitlistOffered {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s is %s and has no offer to accept", what, current.Status)
+	}
+	// El barrido puede tardar en marcarla EXPIRED; la hora de vencimiento es la que manda.
+	if !time.Now().Before(current.OfferExpiresAt.AsTime()) {
+		return nil, status.Errorf(codes.FailedPrecondition, "offer for %s expired at %s", what, current.OfferExpiresAt.AsTime().Format(time.RFC3339))
+	}
+
+	r, err := scanReservation(tx.QueryRowContext(ctx, `
+		UPDATE reservations
+		SET status = 'CONFIRMED', confirmed_at = now(), updated_at = now()
+		WHERE record_id = $1 AND status = 'PENDING'
+		RETURNING `+reservationColumns,
+		current.OfferRecordId))
+	if err == sql.ErrNoRows {
+		return nil, status.Errorf(codes.FailedPrecondition, "offered reservation %s is no longer pending", current.OfferRecordId)
+	} else if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	entry, err := scanWaitlistEntry(tx.QueryRowContext(ctx, `
+		UPDATE waitlist_entries
+		SET status = 'ACCEPTED', updated_at = now()
+		WHERE id = $1
+		RETURNING `+waitlistColumns,
+		req.EntryId))
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	return &pb.AcceptWaitlistOfferResponse{Entry: entry, Reservation: r}, nil
+}
+
+func (s *reservationServer) ListWaitlist(ctx context.Context, req *pb.ListWaitlistRequest) (*pb.ListWaitlistResponse, error) {
+	userID, err := visibleUserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	var br badRequest
+
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize < 0:
+		br.add("pageSize", "pageSize must not be negative")
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+
+	for _, st := range req.Status {
+		if !validWaitlistStatuses[st] {
+			br.add("status", "unknown status %q", st)
+		}
+	}
+
+	// Igual que en ListReservations, el orden de status no invalida el token.
+	statuses := append([]string(nil), req.Status...)
+	sort.Strings(statuses)
+	filters := []any{req.CubicleId, userID, statuses}
+
+	// Sin token se empieza desde el principio: ninguna entrada tiene id vacío.
+	var token waitlistPageToken
+	if req.PageToken != "" {
+		if err := pagetoken.Decode(req.PageToken, &token, filters...); err != nil {
+			br.add("pageToken", "%v", err)
+		} else if token.ID == "" {
+			br.add("pageToken", "%v", pagetoken.ErrMalformed)
+		}
+	}
+
+	if err := br.err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+waitlistColumns+`
+		FROM waitlist_entries
+		WHERE ($1 = '' OR cubicle_id = $1)
+		  AND ($2 = '' OR user_id = $2)
+		  AND (cardinality($3::waitlist_status[]) = 0 OR status = ANY($3::waitlist_status[]))
+		  AND ($4 = '' OR (created_at, id) > ($5::timestamptz, $4))
+		ORDER BY created_at, id
+		LIMIT $6
+	`, req.CubicleId, userID, pq.Array(req.Status), token.ID, token.Created, pageSize+1)
+	if err != nil {
+		return nil, grpcerr.DB(err, "waitlist")
+	}
+	defer rows.Close()
+
+	var entries []*pb.WaitlistEntry
+	for rows.Next() {
+		e, err := scanWaitlistEntry(rows)
+		if err != nil {
+			return nil, grpcerr.DB(err, "waitlist")
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, grpcerr.DB(err, "waitlist")
+	}
+
+	resp := &pb.ListWaitlistResponse{}
+	if len(entries) > pageSize {
+		// Se pidió una fila extra solo para saber si hay otra página.
+		entries = entries[:pageSize]
+		last := entries[len(entries)-1]
+		resp.NextPageToken = pagetoken.Encode(waitlistPageToken{Created: last.CreatedAt.AsTime(), ID: last.Id}, filters...)
+	}
+	resp.Entries = entries
+	return resp, nil
+}
+
+// promoteWaitlist ofrece el cubículo a su lista de espera: recorre las entradas WAITING en
+// orden de llegada y, a cada una cuyo intervalo completo esté libre y respete la política
+// de su usuario, le aparta una reserva PENDING que vence en offerTTL. Regresa las ofertas
+// hechas.
+func (s *reservationServer) promoteWaitlist(ctx context.Context, cubicleID string) ([]*pb.WaitlistEntry, error) {
+	meta, err := s.metaClient.GetMetadata(ctx, &pb.GetMetadataRequest{CubicleId: cubicleID})
+	if err != nil {
+		return nil, grpcerr.Upstream(err, "metadata")
+	}
+	location := meta.GetMetadata().GetLocation()
+	what := "waitlist of cubicle " + cubicleID
+
+	// El calendario se pide antes de abrir la transacción: dentro de ella se tiene el lock
+	// del cubículo y cada llamada a metadata bloquearía sus reservas.
+	calendarChecks, err := s.checkWaitlistCalendars(ctx, cubicleID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	defer tx.Rollback()
+
+	if err := lockCubicle(ctx, tx, cubicleID); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+waitlistColumns+`
+		FROM waitlist_entries
+		WHERE cubicle_id = $1 AND status = 'WAITING' AND start_time > now()
+		ORDER BY created_at, id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, cubicleID, maxWaitlistCandidates)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	var candidates []*pb.WaitlistEntry
+	for rows.Next() {
+		e, err := scanWaitlistEntry(rows)
+		if err != nil {
+			rows.Close()
+			return nil, grpcerr.DB(err, what)
+		}
+		candidates = append(candidates, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	// Un cierre creado después de unirse a la fila también impide la oferta. Una entrada
+	// que llegó después de checkWaitlistCalendars espera a la siguiente promoción.
+	candidates = slices.DeleteFunc(candidates, func(e *pb.WaitlistEntry) bool {
+		violation, checked := calendarChecks[e.Id]
+		return !checked || violation != nil
+	})
+
+	// La oferta cuenta para las cuotas del usuario igual que una reserva nueva, así que se
+	// toma el lock de cada usuario. Se toman todos antes de ofrecer y en orden: dos
+	// promociones de cubículos distintos con usuarios en común los piden en el mismo
+	// orden y no pueden bloquearse entre sí.
+	users := make([]string, 0, len(candidates))
+	for _, e := range candidates {
+		users = append(users, e.UserId)
+	}
+	slices.Sort(users)
+	for _, userID := range slices.Compact(users) {
+		if err := lockUser(ctx, tx, userID); err != nil {
+			return nil, grpcerr.DB(err, what)
+		}
+	}
+
+	now := time.Now()
+	var offered []*pb.WaitlistEntry
+	for _, e := range candidates {
+		window := timerange.Interval{Start: e.Start.AsTime(), End: e.End.AsTime()}
+
+		conflict, err := findOverlappingReservation(ctx, tx, cubicleID, window.Start, window.End)
+		if err != nil {
+			return nil, grpcerr.DB(err, what)
+		}
+		if conflict != nil {
+			continue
+		}
+
+		if err := s.checkActive(ctx, tx, e.UserId, location, now); err == nil {
+			err = s.checkOccurrence(ctx, tx, e.UserId, location, window, false, now)
+		}
+		if err != nil {
+			if _, ok := status.FromError(err); !ok {
+				return nil, grpcerr.DB(err, what)
+			}
+			log.Printf("Waitlist entry %s skipped: %v", e.Id, err)
+			continue
+		}
+
+		recordID := uuid.NewString()
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO reservations (record_id, cubicle_id, user_id, start_time, end_time, status)
+			VALUES ($1, $2, $3, $4, $5, 'PENDING')
+		`, recordID, cubicleID, e.UserId, window.Start, window.End); err != nil {
+			return nil, grpcerr.DB(err, what)
+		}
+
+		entry, err := scanWaitlistEntry(tx.QueryRowContext(ctx, `
+			UPDATE waitlist_entries
+			SET status = 'OFFERED', offer_record_id = $2, offered_at = now(),
+			    offer_expires_at = now() + $3 * interval '1 second', updated_at = now()
+			WHERE id = $1
+			RETURNING `+waitlistColumns,
+			e.Id, recordID, s.offerTTL.Seconds()))
+		if err != nil {
+			return nil, grpcerr.DB(err, what)
+		}
+		offered = append(offered, entry)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	return offered, nil
+}
+
+// checkWaitlistCalendars revisa contra el calendario del cubículo las entradas que
+// promoteWaitlist puede ofrecer y regresa, por id de entrada, la violación o nil. Como en
+// CreateReservation, checkCalendars pide el calendario de todas juntas en lugar de uno por
+// entrada.
+func (s *reservationServer) checkWaitlistCalendars(ctx context.Context, cubicleID string) (map[string]error, error) {
+	what := "waitlist of cubicle " + cubicleID
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, start_time, end_time
+		FROM waitlist_entries
+		WHERE cubicle_id = $1 AND status = 'WAITING' AND start_time > now()
+		ORDER BY created_at, id
+		LIMIT $2
+	`, cubicleID, maxWaitlistCandidates)
+	if err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+	defer rows.Close()
+
+	type waiting struct {
+		id     string
+		window timerange.Interval
+	}
+	var entries []waiting
+	for rows.Next() {
+		var w waiting
+		if err := rows.Scan(&w.id, &w.window.Start, &w.window.End); err != nil {
+			return nil, grpcerr.DB(err, what)
+		}
+		entries = append(entries, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, grpcerr.DB(err, what)
+	}
+
+	// checkCalendars espera las ventanas en orden.
+	sort.Slice(entries, func(i, j int) bool { return entries[i].window.Start.Before(entries[j].window.Start) })
+	windows := make([]timerange.Interval, len(entries))
+	for i, w := range entries {
+		windows[i] = w.window
+	}
+	violations, err := s.checkCalendars(ctx, cubicleID, windows, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make(map[string]error, len(entries))
+	for i, w := range entries {
+		checks[w.id] = violations[i]
+	}
+	return checks, nil
+}
+
+// promoteWaitlistAfterRelease ofrece a la lista de espera el intervalo que acaba de
+// liberarse. Es de mejor esfuerzo: la liberación ya se confirmó, así que un error solo se
+// registra y el barrido vuelve a intentarlo.
+func (s *reservationServer) promoteWaitlistAfterRelease(ctx context.Context, cubicleID string) {
+	offered, err := s.promoteWaitlist(ctx, cubicleID)
+	if err != nil {
+		log.Printf("WARNING: waitlist promotion for cubicle %s failed: %v", cubicleID, err)
+		return
+	}
+	for _, e := range offered {
+		log.Printf("Waitlist entry %s offered reservation %s for cubicle %s until %s",
+			e.Id, e.OfferRecordId, cubicleID, e.OfferExpiresAt.AsTime().Format(time.RFC3339))
+	}
+}
+
+// promoteWaitlistInBackground corre promoteWaitlistAfterRelease sin detener la RPC que
+// liberó el cubículo: la respuesta no espera a metadata ni a los locks de la promoción, y
+// la promoción termina aunque venza el plazo del cliente. main espera a las que sigan en
+// curso antes de cerrar la base de datos.
+func (s *reservationServer) promoteWaitlistInBackground(ctx context.Context, cubicleID string) {
+	work := context.WithoutCancel(ctx)
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		s.promoteWaitlistAfterRelease(work, cubicleID)
+	}()
+}
+
+// expireWaitlistOffers cancela las reservas de las ofertas vencidas y marca sus entradas
+// como EXPIRED; si la reserva ya se había confirmado por otra vía, la entrada queda
+// ACCEPTED. También vence las entradas WAITING cuyo intervalo ya empezó. Como
+// releaseNoShows, es seguro con varias réplicas gracias a FOR UPDATE SKIP LOCKED.
+func (s *reservationServer) expireWaitlistOffers(ctx context.Context) (int64, error) {
+	if _, err := s.db.ExecContext(ctx, `
+		UPDATE waitlist_entries
+		SET status = 'EXPIRED', updated_at = now()
+		WHERE status = 'WAITING' AND start_time <= now()
+	`); err != nil {
+		return 0, err
+	}
+
+	// El UPDATE final ve el estado de las reservas previo al CTE released: una reserva que
+	// seguía PENDING la cancela el CTE y su entrada queda EXPIRED.
+	res, err := s.db.ExecContext(ctx, `
+		WITH expired AS (
+			SELECT id, offer_record_id
+			FROM waitlist_entries
+			WHERE status = 'OFFERED' AND offer_expires_at <= now()
+			ORDER BY offer_expires_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), released AS (
+			UPDATE reservations r
+			SET status = 'CANCELLED', cancelled_at = now(), updated_at = now(), cancel_reason = $2
+			FROM expired e
+			WHERE r.record_id = e.offer_record_id AND r.status = 'PENDING'
+			RETURNING r.record_id
+		)
+		UPDATE waitlist_entries w
+		SET status = CASE WHEN r.status IN ('CONFIRMED', 'CHECKED_IN', 'COMPLETED')
+		                  THEN 'ACCEPTED'::waitlist_status ELSE 'EXPIRED'::waitlist_status END,
+		    updated_at = now()
+		FROM expired e
+		JOIN reservations r ON r.record_id = e.offer_record_id
+		WHERE w.id = e.id
+	`, waitlistSweepBatch, offerExpiredReason)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// waitingCubicles regresa los cubículos con entradas WAITING. El barrido los revisa todos
+// para recuperar promociones que fallaron justo después de una liberación.
+func (s *reservationServer) waitingCubicles(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT cubicle_id
+		FROM waitlist_entries
+		WHERE status = 'WAITING' AND start_time > now()
+		LIMIT $1
+	`, waitlistSweepBatch)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cubicles []string
+	for rows.Next() {
+		var cubicleID string
+		if err := rows.Scan(&cubicleID); err != nil {
+			return nil, err
+		}
+		cubicles = append(cubicles, cubicleID)
+	}
+	return cubicles, rows.Err()
+}
+
+// sweepWaitlist vence ofertas y vuelve a ofrecer los cubículos con fila. Lo llama el
+// barrido periódico de runNoShowSweeper.
+func (s *reservationServer) sweepWaitlist(ctx context.Context) {
+	if expired, err := s.expireWaitlistOffers(ctx); err != nil {
+		log.Printf("waitlist offer expiry failed: %v", err)
+	} else if expired > 0 {
+		log.Printf("%d waitlist offers expired", expired)
+	}
+
+	// waitingCubicles incluye los cubículos de las ofertas recién vencidas que tienen fila.
+	cubicles, err := s.waitingCubicles(ctx)
+	if err != nil {
+		log.Printf("waitlist sweep failed: %v", err)
+		return
+	}
+	for _, cubicleID := range cubicles {
+		s.promoteWaitlistAfterRelease(ctx, cubicleID)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	pb "cubiculosup.com/proto"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListWaitlistPagesPostgres(t *testing.T) {
+	db := testDB(t)
+	s := &reservationServer{db: db}
+	ctx := context.Background()
+	id := testCubicle(t, db)
+
+	// Cinco entradas con created_at distinto para que el orden sea el de inserción.
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	created := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+	var want []string
+	for i := 0; i < 5; i++ {
+		entryID := uuid.NewString()
+		if _, err := db.Exec(`
+			INSERT INTO waitlist_entries (id, cubicle_id, user_id, start_time, end_time, created_at)
+			VALUES ($1, $2, 'test-user', $3, $4, $5)
+		`, entryID, id, start, start.Add(time.Hour), created.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("insert waitlist entry: %v", err)
+		}
+		want = append(want, entryID)
+	}
+
+	var got []string
+	req := &pb.ListWaitlistRequest{CubicleId: id, PageSize: 2}
+	for pages := 0; ; pages++ {
+		if pages > len(want) {
+			t.Fatal("ListWaitlist did not stop paging")
+		}
+		resp, err := s.ListWaitlist(ctx, req)
+		if err != nil {
+			t.Fatalf("ListWaitlist: %v", err)
+		}
+		for _, e := range resp.Entries {
+			got = append(got, e.Id)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("entries = %v, want %v", got, want)
+	}
+
+	// Un token emitido para un cubículo no sirve para otro.
+	first, err := s.ListWaitlist(ctx, &pb.ListWaitlistRequest{CubicleId: id, PageSize: 2})
+	if err != nil {
+		t.Fatalf("ListWaitlist: %v", err)
+	}
+	_, err = s.ListWaitlist(ctx, &pb.ListWaitlistRequest{CubicleId: "other", PageSize: 2, PageToken: first.NextPageToken})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListWaitlist with another cubicle's token = %v, want InvalidArgument", err)
+	}
+}